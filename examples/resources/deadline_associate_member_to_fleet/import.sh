@@ -0,0 +1,2 @@
+# Fleet members are imported by farm ID, fleet ID, principal ID and identity store ID.
+terraform import deadline_associate_member_to_fleet.example farm-0123456789abcdef0123456789abcdef/fleet-0123456789abcdef0123456789abcdef/90670d8a1c-0a1b2c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d/d-9067012345
@@ -0,0 +1,2 @@
+# Queue to fleet associations are imported by farm ID, queue ID and fleet ID.
+terraform import deadline_associate_queue_to_fleet.example farm-0123456789abcdef0123456789abcdef/queue-0123456789abcdef0123456789abcdef/fleet-0123456789abcdef0123456789abcdef
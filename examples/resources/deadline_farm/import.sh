@@ -0,0 +1,4 @@
+# Farms are imported by farm ID, or by display name.
+# Farms are looked up in the region of the provider.
+terraform import deadline_farm.example farm-0123456789abcdef0123456789abcdef
+terraform import deadline_farm.example "name:Render Farm"
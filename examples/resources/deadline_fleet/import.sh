@@ -0,0 +1,4 @@
+# Fleets are imported by farm ID and fleet ID, or by farm ID and display name.
+# Fleets are looked up in the region of the provider.
+terraform import deadline_fleet.example farm-0123456789abcdef0123456789abcdef/fleet-0123456789abcdef0123456789abcdef
+terraform import deadline_fleet.example "farm-0123456789abcdef0123456789abcdef/name:GPU Fleet"
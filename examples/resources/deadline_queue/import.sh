@@ -0,0 +1,4 @@
+# Queues are imported by farm ID and queue ID, or by farm ID and display name.
+# Queues are looked up in the region of the provider.
+terraform import deadline_queue.example farm-0123456789abcdef0123456789abcdef/queue-0123456789abcdef0123456789abcdef
+terraform import deadline_queue.example "farm-0123456789abcdef0123456789abcdef/name:Render Queue"
@@ -0,0 +1,2 @@
+# Queue environments are imported by farm ID, queue ID and queue environment ID.
+terraform import deadline_queue_environment.example farm-0123456789abcdef0123456789abcdef/queue-0123456789abcdef0123456789abcdef/queueenv-0123456789abcdef0123456789abcdef
@@ -0,0 +1,4 @@
+# Storage profiles are imported by farm ID and storage profile ID, or by farm ID and display name.
+# Storage profiles are looked up in the region of the provider.
+terraform import deadline_storage_profile.example farm-0123456789abcdef0123456789abcdef/sp-0123456789abcdef0123456789abcdef
+terraform import deadline_storage_profile.example "farm-0123456789abcdef0123456789abcdef/name:Windows Workstations"
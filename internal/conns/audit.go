@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// readOnlyOperationPrefixes are the prefixes of the API operations that do
+// not change anything and are not audited.
+var readOnlyOperationPrefixes = []string{"Get", "List", "Search", "Describe"}
+
+// AuditLog appends one JSON line per mutating API call to a local file.
+type AuditLog struct {
+	// Caller returns the ARN of the caller identity recorded in every entry.
+	Caller func(ctx context.Context) (string, error)
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+type auditEntry struct {
+	Time      time.Time         `json:"time"`
+	Caller    string            `json:"caller,omitempty"`
+	Operation string            `json:"operation"`
+	Region    string            `json:"region,omitempty"`
+	Resources map[string]string `json:"resources,omitempty"`
+	Input     any               `json:"input"`
+	RequestID string            `json:"request_id,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// NewAuditLog opens, and creates if needed, the audit log file at path.
+// Entries are appended, so several provider processes can share a file.
+func NewAuditLog(path string) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{file: file}, nil
+}
+
+// AddMiddleware adds the audit log to an SDK middleware stack.
+func (l *AuditLog) AddMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("DeadlineProviderAuditLog", l.audit), middleware.After)
+}
+
+func (l *AuditLog) audit(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	operation := awsmiddleware.GetOperationName(ctx)
+	if !isMutatingOperation(operation) {
+		return next.HandleInitialize(ctx, in)
+	}
+	out, metadata, err := next.HandleInitialize(ctx, in)
+
+	input := redactPayload(in.Parameters)
+	entry := auditEntry{
+		Time:      time.Now().UTC(),
+		Operation: operation,
+		Region:    awsmiddleware.GetRegion(ctx),
+		Resources: resourceIdentifiers(input, redactPayload(out.Result)),
+		Input:     input,
+	}
+	if l.Caller != nil {
+		if caller, callerErr := l.Caller(ctx); callerErr == nil {
+			entry.Caller = caller
+		}
+	}
+	if requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+		entry.RequestID = requestID
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	// The API call already happened, so a failure to audit it is logged
+	// rather than returned.
+	if writeErr := l.write(entry); writeErr != nil {
+		tflog.Error(ctx, "unable to write audit log entry", map[string]any{
+			"operation": operation,
+			"error":     writeErr.Error(),
+		})
+	}
+	return out, metadata, err
+}
+
+// write appends entry as a single line and flushes it to disk.
+func (l *AuditLog) write(entry auditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding audit log entry: %w", err)
+	}
+	line = append(line, '\n')
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(line); err != nil {
+		return err
+	}
+	return l.file.Sync()
+}
+
+func isMutatingOperation(operation string) bool {
+	for _, prefix := range readOnlyOperationPrefixes {
+		if strings.HasPrefix(operation, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceIdentifiers collects the top level identifier and ARN fields of
+// the given payloads, for example FarmId or ResourceArn.
+func resourceIdentifiers(payloads ...any) map[string]string {
+	identifiers := make(map[string]string)
+	for _, payload := range payloads {
+		fields, ok := payload.(map[string]any)
+		if !ok {
+			continue
+		}
+		for key, value := range fields {
+			s, ok := value.(string)
+			if !ok || s == "" {
+				continue
+			}
+			if strings.HasSuffix(key, "Id") || strings.HasSuffix(key, "Arn") {
+				identifiers[key] = s
+			}
+		}
+	}
+	return identifiers
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conns
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestAuditLogConcurrentWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := NewAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := auditLog.write(auditEntry{Operation: "UpdateFleet"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry auditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("line %d is not valid JSON: %s", lines+1, err)
+		}
+		lines++
+	}
+	if lines != 20 {
+		t.Errorf("audit log has %d lines, want 20", lines)
+	}
+}
+
+func TestIsMutatingOperation(t *testing.T) {
+	for operation, want := range map[string]bool{
+		"CreateFarm":            true,
+		"AssociateMemberToFarm": true,
+		"TagResource":           true,
+		"GetFleet":              false,
+		"ListTagsForResource":   false,
+	} {
+		if got := isMutatingOperation(operation); got != want {
+			t.Errorf("isMutatingOperation(%q) = %t, want %t", operation, got, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conns
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/deadline"
+	"github.com/aws/aws-sdk-go-v2/service/identitystore"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tags"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"sync"
+)
+
+// AWSClient holds the service clients built by the provider. It is passed to
+// every resource and data source as provider data.
+type AWSClient struct {
+	DeadlineClient      *deadline.Client
+	DeadlineClients     *DeadlineClientPool
+	IdentityStoreClient *identitystore.Client
+	STSClient           *sts.Client
+
+	Region            string
+	DefaultFarmID     string
+	ReadOnly          bool
+	DefaultTagsConfig *tags.DefaultConfig
+	IgnoreTagsConfig  *tags.IgnoreConfig
+
+	identityMu sync.Mutex
+	accountID  string
+	partition  string
+	callerARN  string
+}
+
+// CheckWritable returns an error diagnostic when the provider is read only.
+// Resources call it before any API call of Create, Update and Delete.
+func (c *AWSClient) CheckWritable(operation, typeName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if c.ReadOnly {
+		diags.AddError(
+			"Provider is read only",
+			fmt.Sprintf("Unable to %s %s: the provider is configured with read_only = true, which only allows reading and importing resources.", operation, typeName),
+		)
+	}
+	return diags
+}
+
+// DeadlineClientForRegion returns the Deadline client of region, or the
+// provider Deadline client when region is empty or the provider region.
+func (c *AWSClient) DeadlineClientForRegion(region string) *deadline.Client {
+	if region == "" || region == c.Region || c.DeadlineClients == nil {
+		return c.DeadlineClient
+	}
+	return c.DeadlineClients.Client(region)
+}
+
+// DeadlineARN returns the ARN of a Deadline resource in region, for example
+// "farm/farm-0123" or "farm/farm-0123/queue/queue-0123". An empty region
+// means the provider region.
+func (c *AWSClient) DeadlineARN(ctx context.Context, region, resource string) (string, error) {
+	accountID, partition, err := c.CallerIdentity(ctx)
+	if err != nil {
+		return "", err
+	}
+	if region == "" {
+		region = c.Region
+	}
+	return arn.ARN{
+		Partition: partition,
+		Service:   "deadline",
+		Region:    region,
+		AccountID: accountID,
+		Resource:  resource,
+	}.String(), nil
+}
+
+// CallerIdentity returns the account ID and partition of the configured
+// credentials. They are resolved with STS once and cached, either by the
+// provider credentials validation or by the first resource that needs them.
+func (c *AWSClient) CallerIdentity(ctx context.Context) (string, string, error) {
+	c.identityMu.Lock()
+	defer c.identityMu.Unlock()
+	if c.accountID != "" {
+		return c.accountID, c.partition, nil
+	}
+	output, err := c.STSClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", "", fmt.Errorf("resolving caller identity: %w", err)
+	}
+	callerARN, err := arn.Parse(*output.Arn)
+	if err != nil {
+		return "", "", fmt.Errorf("parsing caller identity ARN: %w", err)
+	}
+	c.accountID = *output.Account
+	c.partition = callerARN.Partition
+	c.callerARN = *output.Arn
+	return c.accountID, c.partition, nil
+}
+
+// DeadlineClientPool lazily creates and caches one Deadline client per region.
+// All clients share the AWS configuration and options of the provider.
+type DeadlineClientPool struct {
+	cfg    aws.Config
+	optFns []func(*deadline.Options)
+
+	mu      sync.Mutex
+	clients map[string]*deadline.Client
+}
+
+// NewDeadlineClientPool returns a pool creating clients from cfg and optFns.
+func NewDeadlineClientPool(cfg aws.Config, optFns ...func(*deadline.Options)) *DeadlineClientPool {
+	return &DeadlineClientPool{
+		cfg:     cfg,
+		optFns:  optFns,
+		clients: make(map[string]*deadline.Client),
+	}
+}
+
+// Client returns the Deadline client of region, creating it on first use.
+func (p *DeadlineClientPool) Client(region string) *deadline.Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if client, ok := p.clients[region]; ok {
+		return client
+	}
+	optFns := append([]func(*deadline.Options){func(o *deadline.Options) {
+		o.Region = region
+	}}, p.optFns...)
+	client := deadline.NewFromConfig(p.cfg, optFns...)
+	p.clients[region] = client
+	return client
+}
+
+// CallerARN returns the ARN of the configured credentials, resolving the
+// caller identity like CallerIdentity.
+func (c *AWSClient) CallerARN(ctx context.Context) (string, error) {
+	if _, _, err := c.CallerIdentity(ctx); err != nil {
+		return "", err
+	}
+	c.identityMu.Lock()
+	defer c.identityMu.Unlock()
+	return c.callerARN, nil
+}
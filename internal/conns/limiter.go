@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conns
+
+import (
+	"context"
+	"github.com/aws/smithy-go/middleware"
+	"golang.org/x/time/rate"
+)
+
+// Limiter bounds the number of concurrent AWS API requests and their rate.
+// A single Limiter is shared by every client of the provider, so all the
+// resources of a Terraform run share one budget. Every attempt of a request,
+// including retries, goes through the Limiter.
+type Limiter struct {
+	sem  chan struct{}
+	rate *rate.Limiter
+}
+
+// NewLimiter returns a Limiter allowing maxConcurrent requests in flight and
+// requestsPerSecond requests per second. A zero value disables the matching
+// limit, and NewLimiter returns nil when both are zero.
+func NewLimiter(maxConcurrent int, requestsPerSecond float64) *Limiter {
+	if maxConcurrent <= 0 && requestsPerSecond <= 0 {
+		return nil
+	}
+	l := &Limiter{}
+	if maxConcurrent > 0 {
+		l.sem = make(chan struct{}, maxConcurrent)
+	}
+	if requestsPerSecond > 0 {
+		// Allow a burst of one second worth of requests, and at least one.
+		burst := max(int(requestsPerSecond), 1)
+		l.rate = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+	return l
+}
+
+// AddMiddleware adds the Limiter to an SDK middleware stack. It is meant to
+// be appended to the APIOptions of a client or of an aws.Config.
+func (l *Limiter) AddMiddleware(stack *middleware.Stack) error {
+	return stack.Finalize.Insert(l, "Retry", middleware.After)
+}
+
+// ID implements middleware.FinalizeMiddleware.
+func (l *Limiter) ID() string {
+	return "DeadlineProviderLimiter"
+}
+
+// HandleFinalize implements middleware.FinalizeMiddleware.
+func (l *Limiter) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+	if l.rate != nil {
+		if err := l.rate.Wait(ctx); err != nil {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+		}
+	}
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, ctx.Err()
+		}
+		defer func() { <-l.sem }()
+	}
+	return next.HandleFinalize(ctx, in)
+}
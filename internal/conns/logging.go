@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conns
+
+import (
+	"context"
+	"encoding/json"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strings"
+	"time"
+)
+
+// LogSubsystem is the tflog subsystem of the AWS API request logs. Its level
+// is set with the TF_LOG_PROVIDER_DEADLINE environment variable.
+const LogSubsystem = "deadline"
+
+// redactedFields are the payload fields, matched case insensitively by
+// suffix, whose values are never logged.
+var redactedFields = []string{"password", "secret", "token", "template"}
+
+// LogFields returns ctx with the given resource identifiers set as fields of
+// every log line, including the request logs. Null and unknown values are
+// skipped.
+func LogFields(ctx context.Context, fields map[string]types.String) context.Context {
+	for key, value := range fields {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		ctx = tflog.SetField(ctx, key, value.ValueString())
+	}
+	return ctx
+}
+
+// AddLoggingMiddleware adds the request logging middleware to an SDK
+// middleware stack. It logs every operation with its request ID, latency,
+// retry count and redacted payloads.
+func AddLoggingMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("DeadlineProviderLogging", logRequest), middleware.After)
+}
+
+func logRequest(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER", "DEADLINE"), tflog.WithRootFields())
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "operation", awsmiddleware.GetOperationName(ctx))
+	tflog.SubsystemDebug(ctx, LogSubsystem, "sending request", map[string]any{
+		"request": redactPayload(in.Parameters),
+	})
+
+	start := time.Now()
+	out, metadata, err := next.HandleInitialize(ctx, in)
+
+	fields := map[string]any{
+		"latency_ms": time.Since(start).Milliseconds(),
+	}
+	if requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+		fields["request_id"] = requestID
+	}
+	if attempts, ok := retry.GetAttemptResults(metadata); ok && len(attempts.Results) > 0 {
+		fields["retries"] = len(attempts.Results) - 1
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, LogSubsystem, "request failed", fields)
+		return out, metadata, err
+	}
+	fields["response"] = redactPayload(out.Result)
+	tflog.SubsystemDebug(ctx, LogSubsystem, "received response", fields)
+	return out, metadata, err
+}
+
+// redactPayload returns the JSON representation of an SDK input or output
+// with the values of the redacted fields masked.
+func redactPayload(payload any) any {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "<unavailable>"
+	}
+	var value any
+	if err := json.Unmarshal(b, &value); err != nil {
+		return "<unavailable>"
+	}
+	return redactValue(value)
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		// The SDK result metadata is not part of the payload.
+		delete(v, "ResultMetadata")
+		for key, field := range v {
+			if isRedacted(key) && field != nil {
+				v[key] = "<redacted>"
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+func isRedacted(key string) bool {
+	key = strings.ToLower(key)
+	for _, field := range redactedFields {
+		if strings.HasSuffix(key, field) {
+			return true
+		}
+	}
+	return false
+}
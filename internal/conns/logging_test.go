@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conns
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/deadline"
+)
+
+func TestRedactPayload(t *testing.T) {
+	got := redactPayload(&deadline.CreateQueueEnvironmentInput{
+		FarmId:   aws.String("farm-0123"),
+		Template: aws.String("specificationVersion: environment-2023-09"),
+	})
+	want := map[string]any{
+		"FarmId":       "farm-0123",
+		"Template":     "<redacted>",
+		"ClientToken":  nil,
+		"Priority":     nil,
+		"QueueId":      nil,
+		"TemplateType": "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("redactPayload() = %v, want %v", got, want)
+	}
+}
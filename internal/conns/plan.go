@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conns
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ModifyPlanFarmID resolves the farm_id attribute of a resource. When the
+// resource does not configure a farm_id, the provider default_farm_id is
+// planned instead. A change of the resolved value requires replacing the
+// resource, exactly as if farm_id was changed in the configuration.
+func (c *AWSClient) ModifyPlanFarmID(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanDefault(ctx, req, resp, path.Root("farm_id"), c.DefaultFarmID,
+		"Missing farm_id",
+		"The farm_id attribute must be set on the resource, or default_farm_id must be set on the provider.",
+	)
+}
+
+// ModifyPlanRegion resolves the region attribute of a resource. When the
+// resource does not configure a region, the provider region is planned on
+// create, and the region of the existing resource is kept afterwards, so that
+// changing the provider region does not move existing resources. A change of
+// the resolved value requires replacing the resource.
+func (c *AWSClient) ModifyPlanRegion(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	p := path.Root("region")
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var configValue, stateValue types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &configValue)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &stateValue)...)
+		// State written before the region attribute existed has no region;
+		// those resources live in the provider region.
+		if stateValue.IsNull() {
+			stateValue = types.StringValue(c.Region)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planValue := configValue
+	if configValue.IsNull() {
+		planValue = stateValue
+		if planValue.ValueString() == "" {
+			if c.Region == "" {
+				resp.Diagnostics.AddAttributeError(p, "Missing region",
+					"The region attribute must be set on the resource, or a region must be configured on the provider.")
+				return
+			}
+			planValue = types.StringValue(c.Region)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p, planValue)...)
+	}
+	if !req.State.Raw.IsNull() && !planValue.Equal(stateValue) {
+		resp.RequiresReplace = append(resp.RequiresReplace, p)
+	}
+}
+
+// modifyPlanDefault plans defaultValue for the string attribute at p when its
+// configuration is null, and requires replacement when the planned value
+// differs from the prior state.
+func modifyPlanDefault(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, p path.Path, defaultValue, missingSummary, missingDetail string) {
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var configValue types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &configValue)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planValue := configValue
+	if configValue.IsNull() {
+		if defaultValue == "" {
+			resp.Diagnostics.AddAttributeError(p, missingSummary, missingDetail)
+			return
+		}
+		planValue = types.StringValue(defaultValue)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p, planValue)...)
+	}
+	// Nothing to replace on create.
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var stateValue types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &stateValue)...)
+	if !planValue.Equal(stateValue) {
+		resp.RequiresReplace = append(resp.RequiresReplace, p)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestModifyPlanRegion(t *testing.T) {
+	ctx := context.Background()
+	resourceSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{Optional: true, Computed: true},
+		},
+	}
+	objectType := resourceSchema.Type().TerraformType(ctx)
+	// value returns the raw object with the given region; "-" is a null
+	// object and "" a null region.
+	value := func(region string) tftypes.Value {
+		switch region {
+		case "-":
+			return tftypes.NewValue(objectType, nil)
+		case "":
+			return tftypes.NewValue(objectType, map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, nil)})
+		}
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, region)})
+	}
+
+	tests := []struct {
+		name           string
+		providerRegion string
+		config         string
+		state          string
+		wantRegion     string
+		wantReplace    bool
+		wantErr        bool
+	}{
+		{name: "create with the provider region", providerRegion: "us-west-2", config: "", state: "-", wantRegion: "us-west-2"},
+		{name: "create with a configured region", providerRegion: "us-west-2", config: "eu-west-1", state: "-", wantRegion: "eu-west-1"},
+		{name: "create without any region", config: "", state: "-", wantErr: true},
+		{name: "unchanged", providerRegion: "us-west-2", config: "", state: "us-west-2", wantRegion: "us-west-2"},
+		{name: "state without region", providerRegion: "us-west-2", config: "", state: "", wantRegion: "us-west-2"},
+		{name: "state without region, configured", providerRegion: "us-west-2", config: "us-west-2", state: "", wantRegion: "us-west-2"},
+		{name: "provider region changed", providerRegion: "eu-west-1", config: "", state: "us-west-2", wantRegion: "us-west-2"},
+		{name: "configured region changed", providerRegion: "us-west-2", config: "eu-west-1", state: "us-west-2", wantRegion: "eu-west-1", wantReplace: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: resourceSchema, Raw: value(tt.config)},
+				State:  tfsdk.State{Schema: resourceSchema, Raw: value(tt.state)},
+				Plan:   tfsdk.Plan{Schema: resourceSchema, Raw: value(tt.config)},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+			client := &AWSClient{Region: tt.providerRegion}
+			client.ModifyPlanRegion(ctx, req, resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("ModifyPlanRegion() diagnostics = %v, want error %t", resp.Diagnostics, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var region types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("region"), &region)...)
+			if region.ValueString() != tt.wantRegion {
+				t.Errorf("planned region = %s, want %s", region, tt.wantRegion)
+			}
+			if got := len(resp.RequiresReplace) > 0; got != tt.wantReplace {
+				t.Errorf("RequiresReplace = %v, want replace %t", resp.RequiresReplace, tt.wantReplace)
+			}
+		})
+	}
+}
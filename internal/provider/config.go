@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// serviceEndpoints holds the resolved endpoint overrides of each service
+// client. A nil value keeps the default regional endpoint.
+type serviceEndpoints struct {
+	deadline      *string
+	identityStore *string
+	sts           *string
+}
+
+// resolveEndpoints merges the endpoints block with the service specific
+// AWS_ENDPOINT_URL_* environment variables. The provider configuration wins.
+func resolveEndpoints(data *EndpointsModel) serviceEndpoints {
+	if data == nil {
+		data = &EndpointsModel{}
+	}
+	return serviceEndpoints{
+		deadline:      endpointValue(data.Deadline, "AWS_ENDPOINT_URL_DEADLINE"),
+		identityStore: endpointValue(data.IdentityStore, "AWS_ENDPOINT_URL_IDENTITYSTORE"),
+		sts:           endpointValue(data.STS, "AWS_ENDPOINT_URL_STS"),
+	}
+}
+
+func endpointValue(value types.String, envVar string) *string {
+	if value.ValueString() != "" {
+		return value.ValueStringPointer()
+	}
+	if v := os.Getenv(envVar); v != "" {
+		return aws.String(v)
+	}
+	return nil
+}
+
+// newSTSClient builds an STS client honouring the sts endpoint override.
+func newSTSClient(cfg aws.Config, endpoints serviceEndpoints) *sts.Client {
+	return sts.NewFromConfig(cfg, func(o *sts.Options) {
+		o.BaseEndpoint = endpoints.sts
+	})
+}
+
+// loadAWSConfig builds the AWS SDK configuration from the provider data model.
+// Attributes that are not set fall back to the SDK defaults, which means the
+// standard AWS_* environment variables and shared files still apply.
+func loadAWSConfig(ctx context.Context, data AWSDeadlineProviderModel, endpoints serviceEndpoints, retries retrySettings) (aws.Config, diag.Diagnostics) {
+	httpClient, diags := newHTTPClient(data)
+	if diags.HasError() {
+		return aws.Config{}, diags
+	}
+	optFns := []func(*config.LoadOptions) error{
+		config.WithRetryer(retries.retryer()),
+		config.WithHTTPClient(httpClient),
+	}
+
+	if data.Region.ValueString() != "" {
+		optFns = append(optFns, config.WithRegion(data.Region.ValueString()))
+	}
+	if !data.UseFIPSEndpoint.IsNull() {
+		state := aws.FIPSEndpointStateDisabled
+		if data.UseFIPSEndpoint.ValueBool() {
+			state = aws.FIPSEndpointStateEnabled
+		}
+		optFns = append(optFns, config.WithUseFIPSEndpoint(state))
+	}
+	if !data.UseDualStackEndpoint.IsNull() {
+		state := aws.DualStackEndpointStateDisabled
+		if data.UseDualStackEndpoint.ValueBool() {
+			state = aws.DualStackEndpointStateEnabled
+		}
+		optFns = append(optFns, config.WithUseDualStackEndpoint(state))
+	}
+	if data.Profile.ValueString() != "" {
+		optFns = append(optFns, config.WithSharedConfigProfile(data.Profile.ValueString()))
+	}
+	if len(data.SharedConfigFiles) > 0 {
+		optFns = append(optFns, config.WithSharedConfigFiles(stringValues(data.SharedConfigFiles)))
+	}
+	if len(data.SharedCredentialsFiles) > 0 {
+		optFns = append(optFns, config.WithSharedCredentialsFiles(stringValues(data.SharedCredentialsFiles)))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		diags.AddError(
+			"Unable to load AWS SDK configuration",
+			fmt.Sprintf("The provider could not load the AWS SDK configuration, got error: %s", err),
+		)
+		return cfg, diags
+	}
+
+	if data.AssumeRoleWithWebIdentity != nil {
+		diags.Append(assumeRoleWithWebIdentity(&cfg, data.AssumeRoleWithWebIdentity, endpoints)...)
+		if diags.HasError() {
+			return cfg, diags
+		}
+	}
+	if data.AssumeRole != nil {
+		diags.Append(assumeRole(&cfg, data.AssumeRole, endpoints)...)
+	}
+	return cfg, diags
+}
+
+// minSessionDuration is the shortest role session STS accepts.
+const minSessionDuration = 15 * time.Minute
+
+// parseDuration parses an optional session duration attribute.
+func parseDuration(block string, value types.String) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.ValueString() == "" {
+		return 0, diags
+	}
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Invalid %s configuration", block), fmt.Sprintf("Unable to parse duration %q, got error: %s", value.ValueString(), err))
+	}
+	return duration, diags
+}
+
+// assumeRole replaces the credentials of cfg with temporary credentials for
+// the role described by the assume_role block. The loaded credentials are used
+// to call STS.
+func assumeRole(cfg *aws.Config, data *AssumeRoleModel, endpoints serviceEndpoints) diag.Diagnostics {
+	if data.RoleARN.ValueString() == "" {
+		return diag.Diagnostics{
+			diag.NewErrorDiagnostic("Invalid assume_role configuration", "The role_arn attribute is required when the assume_role block is set."),
+		}
+	}
+	duration, diags := parseDuration("assume_role", data.Duration)
+	if diags.HasError() {
+		return diags
+	}
+
+	credentialsProvider := stscreds.NewAssumeRoleProvider(newSTSClient(*cfg, endpoints), data.RoleARN.ValueString(), func(o *stscreds.AssumeRoleOptions) {
+		if data.SessionName.ValueString() != "" {
+			o.RoleSessionName = data.SessionName.ValueString()
+		}
+		if data.ExternalID.ValueString() != "" {
+			o.ExternalID = data.ExternalID.ValueStringPointer()
+		}
+		if duration > 0 {
+			o.Duration = duration
+		}
+		if data.Policy.ValueString() != "" {
+			o.Policy = data.Policy.ValueStringPointer()
+		}
+		for _, policyARN := range stringValues(data.PolicyARNs) {
+			o.PolicyARNs = append(o.PolicyARNs, ststypes.PolicyDescriptorType{
+				Arn: aws.String(policyARN),
+			})
+		}
+		for key, value := range data.Tags {
+			o.Tags = append(o.Tags, ststypes.Tag{
+				Key:   aws.String(key),
+				Value: value.ValueStringPointer(),
+			})
+		}
+	})
+	cfg.Credentials = aws.NewCredentialsCache(credentialsProvider)
+	return diags
+}
+
+// validateAccountID checks the caller account against the allowed_account_ids
+// and forbidden_account_ids attributes.
+func validateAccountID(accountID string, allowed, forbidden []types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	allowedIDs := stringValues(allowed)
+	forbiddenIDs := stringValues(forbidden)
+	// The schema already rejects this configuration; checking again keeps the
+	// guard rails safe should the validator be bypassed.
+	if len(allowedIDs) > 0 && len(forbiddenIDs) > 0 {
+		diags.AddError("Invalid account configuration", "Only one of allowed_account_ids and forbidden_account_ids can be set.")
+		return diags
+	}
+	if len(allowedIDs) > 0 && !slices.Contains(allowedIDs, accountID) {
+		diags.AddError(
+			"AWS account not allowed",
+			fmt.Sprintf("The configured credentials belong to AWS account %s, which is not in allowed_account_ids (%s).", accountID, strings.Join(allowedIDs, ", ")),
+		)
+	}
+	if slices.Contains(forbiddenIDs, accountID) {
+		diags.AddError(
+			"AWS account forbidden",
+			fmt.Sprintf("The configured credentials belong to AWS account %s, which is listed in forbidden_account_ids.", accountID),
+		)
+	}
+	return diags
+}
+
+// stringValues converts a list of framework strings into plain strings,
+// dropping null and empty entries.
+func stringValues(values []types.String) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v.ValueString() != "" {
+			result = append(result, v.ValueString())
+		}
+	}
+	return result
+}
+
+// webIdentityToken is an inline OIDC token passed through the provider configuration.
+type webIdentityToken string
+
+func (t webIdentityToken) GetIdentityToken() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// assumeRoleWithWebIdentity replaces the credentials of cfg with temporary
+// credentials obtained by exchanging an OIDC token for the configured role.
+func assumeRoleWithWebIdentity(cfg *aws.Config, data *AssumeRoleWithWebIdentityModel, endpoints serviceEndpoints) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.RoleARN.ValueString() == "" {
+		diags.AddError("Invalid assume_role_with_web_identity configuration", "The role_arn attribute is required when the assume_role_with_web_identity block is set.")
+		return diags
+	}
+	if data.WebIdentityToken.ValueString() != "" && data.WebIdentityTokenFile.ValueString() != "" {
+		diags.AddError("Invalid assume_role_with_web_identity configuration", "Only one of web_identity_token and web_identity_token_file can be set.")
+		return diags
+	}
+
+	var tokenRetriever stscreds.IdentityTokenRetriever
+	switch {
+	case data.WebIdentityToken.ValueString() != "":
+		tokenRetriever = webIdentityToken(data.WebIdentityToken.ValueString())
+	case data.WebIdentityTokenFile.ValueString() != "":
+		tokenRetriever = stscreds.IdentityTokenFile(data.WebIdentityTokenFile.ValueString())
+	case os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE") != "":
+		tokenRetriever = stscreds.IdentityTokenFile(os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"))
+	default:
+		diags.AddError("Invalid assume_role_with_web_identity configuration", "One of web_identity_token or web_identity_token_file must be set, or the AWS_WEB_IDENTITY_TOKEN_FILE environment variable must point to a token file.")
+		return diags
+	}
+
+	duration, diags := parseDuration("assume_role_with_web_identity", data.Duration)
+	if diags.HasError() {
+		return diags
+	}
+
+	credentialsProvider := stscreds.NewWebIdentityRoleProvider(newSTSClient(*cfg, endpoints), data.RoleARN.ValueString(), tokenRetriever, func(o *stscreds.WebIdentityRoleOptions) {
+		if data.SessionName.ValueString() != "" {
+			o.RoleSessionName = data.SessionName.ValueString()
+		}
+		if duration > 0 {
+			o.Duration = duration
+		}
+	})
+	cfg.Credentials = aws.NewCredentialsCache(credentialsProvider)
+	return diags
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateAccountID(t *testing.T) {
+	accounts := func(ids ...string) []types.String {
+		values := make([]types.String, 0, len(ids))
+		for _, id := range ids {
+			values = append(values, types.StringValue(id))
+		}
+		return values
+	}
+	testCases := map[string]struct {
+		allowed   []types.String
+		forbidden []types.String
+		wantError bool
+	}{
+		"no guard rails":   {},
+		"allowed account":  {allowed: accounts("111111111111", "123456789012")},
+		"not allowed":      {allowed: accounts("111111111111"), wantError: true},
+		"forbidden":        {forbidden: accounts("123456789012"), wantError: true},
+		"not forbidden":    {forbidden: accounts("111111111111")},
+		"both lists given": {allowed: accounts("123456789012"), forbidden: accounts("111111111111"), wantError: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diags := validateAccountID("123456789012", tc.allowed, tc.forbidden)
+			if diags.HasError() != tc.wantError {
+				t.Errorf("validateAccountID() errors = %v, want error %t", diags, tc.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"golang.org/x/net/http/httpproxy"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// newHTTPClient builds the HTTP client shared by the AWS service clients from
+// the network attributes of the provider. Proxy attributes that are not set
+// fall back to the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+// variables.
+func newHTTPClient(data AWSDeadlineProviderModel) (*awshttp.BuildableClient, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	proxyConfig := httpproxy.FromEnvironment()
+	if data.HTTPProxy.ValueString() != "" {
+		proxyConfig.HTTPProxy = data.HTTPProxy.ValueString()
+	}
+	if data.HTTPSProxy.ValueString() != "" {
+		proxyConfig.HTTPSProxy = data.HTTPSProxy.ValueString()
+	}
+	if data.NoProxy.ValueString() != "" {
+		proxyConfig.NoProxy = data.NoProxy.ValueString()
+	}
+	proxyFunc := proxyConfig.ProxyFunc()
+
+	var rootCAs *x509.CertPool
+	if data.CustomCABundle.ValueString() != "" {
+		pem, err := os.ReadFile(data.CustomCABundle.ValueString())
+		if err != nil {
+			diags.AddError("Invalid custom_ca_bundle", fmt.Sprintf("Unable to read the CA bundle %q, got error: %s", data.CustomCABundle.ValueString(), err))
+			return nil, diags
+		}
+		rootCAs, err = x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			diags.AddError("Invalid custom_ca_bundle", fmt.Sprintf("The CA bundle %q does not contain any PEM encoded certificate.", data.CustomCABundle.ValueString()))
+			return nil, diags
+		}
+	}
+
+	timeout, d := parseDuration("http_timeout", data.HTTPTimeout)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	client := awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+		tr.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+		if tr.TLSClientConfig == nil {
+			tr.TLSClientConfig = &tls.Config{}
+		}
+		if rootCAs != nil {
+			tr.TLSClientConfig.RootCAs = rootCAs
+		}
+		if data.Insecure.ValueBool() {
+			tr.TLSClientConfig.InsecureSkipVerify = true //nolint:gosec // Opt-in for lab environments only.
+		}
+	})
+	if timeout > 0 {
+		client = client.WithTimeout(timeout)
+	}
+	return client, diags
+}
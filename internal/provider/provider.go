@@ -5,8 +5,10 @@ package provider
 
 import (
 	"context"
-	"github.com/aws/aws-sdk-go-v2/config"
+	"fmt"
 	"github.com/aws/aws-sdk-go-v2/service/deadline"
+	"github.com/aws/aws-sdk-go-v2/service/identitystore"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/conns"
 	associatemembertofarm "github.com/enable-la/terraform-provider-aws-deadline/internal/resources/associate-member-to-farm"
 	associatemembertofleet "github.com/enable-la/terraform-provider-aws-deadline/internal/resources/associate-member-to-fleet"
 	associatequeuetofleet "github.com/enable-la/terraform-provider-aws-deadline/internal/resources/associate-queue-to-fleet"
@@ -16,13 +18,22 @@ import (
 	"github.com/enable-la/terraform-provider-aws-deadline/internal/resources/queue"
 	queueenvironment "github.com/enable-la/terraform-provider-aws-deadline/internal/resources/queue-environment"
 	storageprofile "github.com/enable-la/terraform-provider-aws-deadline/internal/resources/storage-profile"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tags"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tracing"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/verify"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"log"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure AWSDeadlineProvider satisfies various provider interfaces.
@@ -39,7 +50,72 @@ type AWSDeadlineProvider struct {
 
 // AWSDeadlineProviderModel describes the provider data model.
 type AWSDeadlineProviderModel struct {
-	Region types.String `tfsdk:"region"`
+	Region                    types.String                    `tfsdk:"region"`
+	Profile                   types.String                    `tfsdk:"profile"`
+	SharedConfigFiles         []types.String                  `tfsdk:"shared_config_files"`
+	SharedCredentialsFiles    []types.String                  `tfsdk:"shared_credentials_files"`
+	MaxRetries                types.Int64                     `tfsdk:"max_retries"`
+	RetryMode                 types.String                    `tfsdk:"retry_mode"`
+	MaxBackoff                types.String                    `tfsdk:"max_backoff"`
+	MaxConcurrentRequests     types.Int64                     `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond         types.Float64                   `tfsdk:"requests_per_second"`
+	SkipCredentialsValidation types.Bool                      `tfsdk:"skip_credentials_validation"`
+	HTTPProxy                 types.String                    `tfsdk:"http_proxy"`
+	HTTPSProxy                types.String                    `tfsdk:"https_proxy"`
+	NoProxy                   types.String                    `tfsdk:"no_proxy"`
+	CustomCABundle            types.String                    `tfsdk:"custom_ca_bundle"`
+	Insecure                  types.Bool                      `tfsdk:"insecure"`
+	UseFIPSEndpoint           types.Bool                      `tfsdk:"use_fips_endpoint"`
+	UseDualStackEndpoint      types.Bool                      `tfsdk:"use_dualstack_endpoint"`
+	HTTPTimeout               types.String                    `tfsdk:"http_timeout"`
+	AllowedAccountIds         []types.String                  `tfsdk:"allowed_account_ids"`
+	ForbiddenAccountIds       []types.String                  `tfsdk:"forbidden_account_ids"`
+	DefaultFarmID             types.String                    `tfsdk:"default_farm_id"`
+	ReadOnly                  types.Bool                      `tfsdk:"read_only"`
+	AuditLogPath              types.String                    `tfsdk:"audit_log_path"`
+	AssumeRole                *AssumeRoleModel                `tfsdk:"assume_role"`
+	AssumeRoleWithWebIdentity *AssumeRoleWithWebIdentityModel `tfsdk:"assume_role_with_web_identity"`
+	Endpoints                 *EndpointsModel                 `tfsdk:"endpoints"`
+	DefaultTags               *DefaultTagsModel               `tfsdk:"default_tags"`
+	IgnoreTags                *IgnoreTagsModel                `tfsdk:"ignore_tags"`
+}
+
+// IgnoreTagsModel describes the ignore_tags block of the provider.
+type IgnoreTagsModel struct {
+	Keys        []types.String `tfsdk:"keys"`
+	KeyPrefixes []types.String `tfsdk:"key_prefixes"`
+}
+
+// DefaultTagsModel describes the default_tags block of the provider.
+type DefaultTagsModel struct {
+	Tags map[string]types.String `tfsdk:"tags"`
+}
+
+// AssumeRoleModel describes the assume_role block of the provider.
+type AssumeRoleModel struct {
+	RoleARN     types.String            `tfsdk:"role_arn"`
+	SessionName types.String            `tfsdk:"session_name"`
+	ExternalID  types.String            `tfsdk:"external_id"`
+	Duration    types.String            `tfsdk:"duration"`
+	Policy      types.String            `tfsdk:"policy"`
+	PolicyARNs  []types.String          `tfsdk:"policy_arns"`
+	Tags        map[string]types.String `tfsdk:"tags"`
+}
+
+// EndpointsModel describes the endpoints block of the provider.
+type EndpointsModel struct {
+	Deadline      types.String `tfsdk:"deadline"`
+	IdentityStore types.String `tfsdk:"identitystore"`
+	STS           types.String `tfsdk:"sts"`
+}
+
+// AssumeRoleWithWebIdentityModel describes the assume_role_with_web_identity block of the provider.
+type AssumeRoleWithWebIdentityModel struct {
+	RoleARN              types.String `tfsdk:"role_arn"`
+	SessionName          types.String `tfsdk:"session_name"`
+	WebIdentityToken     types.String `tfsdk:"web_identity_token"`
+	WebIdentityTokenFile types.String `tfsdk:"web_identity_token_file"`
+	Duration             types.String `tfsdk:"duration"`
 }
 
 func (p *AWSDeadlineProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -49,11 +125,243 @@ func (p *AWSDeadlineProvider) Metadata(ctx context.Context, req provider.Metadat
 
 func (p *AWSDeadlineProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Blocks: map[string]schema.Block{
+			"assume_role": schema.SingleNestedBlock{
+				Description: "An IAM role to assume with the loaded credentials before calling the Deadline API.",
+				Attributes: map[string]schema.Attribute{
+					"role_arn": schema.StringAttribute{
+						Description: "The ARN of the IAM role to assume.",
+						Optional:    true,
+						Validators: []validator.String{
+							verify.ARN("iam"),
+						},
+					},
+					"session_name": schema.StringAttribute{
+						Description: "The session name to use when assuming the role.",
+						Optional:    true,
+					},
+					"external_id": schema.StringAttribute{
+						Description: "The external ID to use when assuming the role.",
+						Optional:    true,
+					},
+					"duration": schema.StringAttribute{
+						Description: "The duration of the role session, for example `1h` or `15m`. Must be at least 15 minutes. Defaults to 15 minutes.",
+						Optional:    true,
+						Validators: []validator.String{
+							verify.DurationAtLeast(minSessionDuration),
+						},
+					},
+					"policy": schema.StringAttribute{
+						Description: "An IAM policy in JSON format used to further restrict the permissions of the role session.",
+						Optional:    true,
+					},
+					"policy_arns": schema.ListAttribute{
+						ElementType: types.StringType,
+						Description: "A list of managed IAM policy ARNs used to further restrict the permissions of the role session.",
+						Optional:    true,
+					},
+					"tags": schema.MapAttribute{
+						ElementType: types.StringType,
+						Description: "The session tags to pass when assuming the role.",
+						Optional:    true,
+					},
+				},
+			},
+			"assume_role_with_web_identity": schema.SingleNestedBlock{
+				Description: "An IAM role to assume with an OIDC web identity token, for example from a CI pipeline.",
+				Attributes: map[string]schema.Attribute{
+					"role_arn": schema.StringAttribute{
+						Description: "The ARN of the IAM role to assume.",
+						Optional:    true,
+						Validators: []validator.String{
+							verify.ARN("iam"),
+						},
+					},
+					"session_name": schema.StringAttribute{
+						Description: "The session name to use when assuming the role.",
+						Optional:    true,
+					},
+					"web_identity_token": schema.StringAttribute{
+						Description: "The OIDC token issued by the identity provider. Conflicts with `web_identity_token_file`.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"web_identity_token_file": schema.StringAttribute{
+						Description: "The path to a file containing the OIDC token issued by the identity provider. Conflicts with `web_identity_token`. Defaults to the `AWS_WEB_IDENTITY_TOKEN_FILE` environment variable.",
+						Optional:    true,
+					},
+					"duration": schema.StringAttribute{
+						Description: "The duration of the role session, for example `1h` or `15m`. Must be at least 15 minutes. Defaults to 15 minutes.",
+						Optional:    true,
+						Validators: []validator.String{
+							verify.DurationAtLeast(minSessionDuration),
+						},
+					},
+				},
+			},
+			"default_tags": schema.SingleNestedBlock{
+				Description: "Tags applied to every taggable resource of the provider. Resource tags override default tags with the same key.",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{
+						ElementType: types.StringType,
+						Description: "The default tags to apply to resources.",
+						Optional:    true,
+					},
+				},
+			},
+			"ignore_tags": schema.SingleNestedBlock{
+				Description: "Tags that are managed outside Terraform. Matching tags are left out of the state of every resource and are never removed.",
+				Attributes: map[string]schema.Attribute{
+					"keys": schema.ListAttribute{
+						ElementType: types.StringType,
+						Description: "Tag keys to ignore.",
+						Optional:    true,
+					},
+					"key_prefixes": schema.ListAttribute{
+						ElementType: types.StringType,
+						Description: "Tag key prefixes to ignore.",
+						Optional:    true,
+					},
+				},
+			},
+			"endpoints": schema.SingleNestedBlock{
+				Description: "Custom endpoints that override the default regional endpoint of each service client.",
+				Attributes: map[string]schema.Attribute{
+					"deadline": schema.StringAttribute{
+						Description: "The endpoint URL of the Deadline API. Can also be set with the `AWS_ENDPOINT_URL_DEADLINE` environment variable.",
+						Optional:    true,
+					},
+					"identitystore": schema.StringAttribute{
+						Description: "The endpoint URL of the Identity Store API. Can also be set with the `AWS_ENDPOINT_URL_IDENTITYSTORE` environment variable.",
+						Optional:    true,
+					},
+					"sts": schema.StringAttribute{
+						Description: "The endpoint URL of the STS API, used when assuming roles. Can also be set with the `AWS_ENDPOINT_URL_STS` environment variable.",
+						Optional:    true,
+					},
+				},
+			},
+		},
 		Attributes: map[string]schema.Attribute{
 			"region": schema.StringAttribute{
 				Description: "The AWS region to use for the Deadline API.",
 				Optional:    true,
 			},
+			"profile": schema.StringAttribute{
+				Description: "The name of the AWS profile to use from the shared configuration and credentials files.",
+				Optional:    true,
+			},
+			"shared_config_files": schema.ListAttribute{
+				ElementType: types.StringType,
+				Description: "A list of paths to AWS shared config files. Defaults to `~/.aws/config`.",
+				Optional:    true,
+			},
+			"shared_credentials_files": schema.ListAttribute{
+				ElementType: types.StringType,
+				Description: "A list of paths to AWS shared credentials files. Defaults to `~/.aws/credentials`.",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "The maximum number of times an API call is retried when it fails with a retryable error, such as throttling. `0` disables retries. Defaults to the AWS SDK default.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_mode": schema.StringAttribute{
+				Description: "The retry mode of the AWS SDK. Valid values are `standard` and `adaptive`. Defaults to `standard`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(retryModeStandard, retryModeAdaptive),
+				},
+			},
+			"max_backoff": schema.StringAttribute{
+				Description: "The maximum delay between two retries of an API call, for example `30s`. Defaults to the AWS SDK default.",
+				Optional:    true,
+				Validators: []validator.String{
+					verify.Duration(),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "The maximum number of concurrent AWS API requests, shared by all the resources of a run. Defaults to no limit.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "The maximum rate of AWS API requests per second, shared by all the resources of a run. Defaults to no limit.",
+				Optional:    true,
+				Validators: []validator.Float64{
+					// The rate must be greater than 0.
+					float64validator.AtLeast(0),
+					float64validator.NoneOf(0),
+				},
+			},
+			"http_proxy": schema.StringAttribute{
+				Description: "The URL of the proxy used for HTTP requests. Defaults to the `HTTP_PROXY` environment variable.",
+				Optional:    true,
+			},
+			"https_proxy": schema.StringAttribute{
+				Description: "The URL of the proxy used for HTTPS requests. Defaults to the `HTTPS_PROXY` environment variable.",
+				Optional:    true,
+			},
+			"no_proxy": schema.StringAttribute{
+				Description: "A comma separated list of hosts that bypass the proxy. Defaults to the `NO_PROXY` environment variable.",
+				Optional:    true,
+			},
+			"custom_ca_bundle": schema.StringAttribute{
+				Description: "The path to a PEM encoded bundle of additional certificate authorities to trust, for example the root CA of a TLS intercepting proxy. Can also be set with the `AWS_CA_BUNDLE` environment variable.",
+				Optional:    true,
+			},
+			"insecure": schema.BoolAttribute{
+				Description: "Skip the verification of TLS certificates. Only use this in lab environments. Defaults to `false`.",
+				Optional:    true,
+			},
+			"http_timeout": schema.StringAttribute{
+				Description: "The timeout of a single HTTP request to the AWS APIs, for example `60s`. Defaults to no timeout.",
+				Optional:    true,
+				Validators: []validator.String{
+					verify.Duration(),
+				},
+			},
+			"use_fips_endpoint": schema.BoolAttribute{
+				Description: "Use the FIPS endpoints of the AWS APIs, for example in the `aws-us-gov` partition. Can also be set with the `AWS_USE_FIPS_ENDPOINT` environment variable.",
+				Optional:    true,
+			},
+			"use_dualstack_endpoint": schema.BoolAttribute{
+				Description: "Use the dual-stack (IPv4 and IPv6) endpoints of the AWS APIs. Can also be set with the `AWS_USE_DUALSTACK_ENDPOINT` environment variable.",
+				Optional:    true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Refuse to create, update or delete any resource. Reading and importing resources keep working, which makes `terraform plan` safe against production farms. Defaults to `false`.",
+				Optional:    true,
+			},
+			"audit_log_path": schema.StringAttribute{
+				Description: "The path of a file to which every mutating Deadline API call is appended as a JSON line, with the caller identity, operation, resource identifiers, redacted input and AWS request ID.",
+				Optional:    true,
+			},
+			"default_farm_id": schema.StringAttribute{
+				Description: "The ID of the farm used by resources that do not set `farm_id`. Changing it replaces those resources.",
+				Optional:    true,
+			},
+			"allowed_account_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Description: "The AWS account IDs the provider is allowed to manage. The provider refuses to configure itself for any other account. Conflicts with `forbidden_account_ids`.",
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("forbidden_account_ids")),
+				},
+			},
+			"forbidden_account_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Description: "The AWS account IDs the provider must not manage. Conflicts with `allowed_account_ids`.",
+				Optional:    true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Description: "Skip validating the credentials with the STS GetCallerIdentity API when the provider is configured. The account ID is then resolved the first time a resource needs it. Defaults to `false`.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -66,16 +374,107 @@ func (p *AWSDeadlineProvider) Configure(ctx context.Context, req provider.Config
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	cfg, err := config.LoadDefaultConfig(context.TODO())
-	if err != nil {
-		log.Fatalf("unable to load SDK config, %v", err)
+	endpoints := resolveEndpoints(data.Endpoints)
+	retries, diags := newRetrySettings(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	cfg, diags := loadAWSConfig(ctx, data, endpoints, retries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	limiter := conns.NewLimiter(int(data.MaxConcurrentRequests.ValueInt64()), data.RequestsPerSecond.ValueFloat64())
+	if limiter != nil {
+		// Every client built from cfg shares the limiter.
+		cfg.APIOptions = append(cfg.APIOptions, limiter.AddMiddleware)
+	}
+	var auditLog *conns.AuditLog
+	if data.AuditLogPath.ValueString() != "" {
+		var err error
+		auditLog, err = conns.NewAuditLog(data.AuditLogPath.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("audit_log_path"),
+				"Unable to open audit log",
+				fmt.Sprintf("The provider could not open the audit log file, got error: %s", err),
+			)
+			return
+		}
+	}
+	deadlineClients := conns.NewDeadlineClientPool(cfg, func(o *deadline.Options) {
+		o.BaseEndpoint = endpoints.deadline
+		o.Retryer = retries.retryer(deadlineRetryables...)()
+		o.APIOptions = append(o.APIOptions, conns.AddLoggingMiddleware, tracing.AddMiddleware)
+		if auditLog != nil {
+			o.APIOptions = append(o.APIOptions, auditLog.AddMiddleware)
+		}
+	})
+	client := &conns.AWSClient{
+		DeadlineClient:  deadlineClients.Client(cfg.Region),
+		DeadlineClients: deadlineClients,
+		IdentityStoreClient: identitystore.NewFromConfig(cfg, func(o *identitystore.Options) {
+			o.BaseEndpoint = endpoints.identityStore
+		}),
+		STSClient:         newSTSClient(cfg, endpoints),
+		Region:            cfg.Region,
+		DefaultFarmID:     data.DefaultFarmID.ValueString(),
+		ReadOnly:          data.ReadOnly.ValueBool(),
+		DefaultTagsConfig: defaultTagsConfig(data.DefaultTags),
+		IgnoreTagsConfig:  ignoreTagsConfig(data.IgnoreTags),
+	}
+	if auditLog != nil {
+		auditLog.Caller = client.CallerARN
 	}
-	if data.Region.ValueString() != "" {
-		cfg.Region = data.Region.ValueString()
+	// The account guard rails need the caller identity even when the
+	// credentials validation is skipped.
+	checkAccountID := len(data.AllowedAccountIds) > 0 || len(data.ForbiddenAccountIds) > 0
+	if !data.SkipCredentialsValidation.ValueBool() || checkAccountID {
+		accountID, partition, err := client.CallerIdentity(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to validate AWS credentials",
+				fmt.Sprintf("The provider could not call STS GetCallerIdentity with the configured credentials. "+
+					"Check the credentials, profile and assume role configuration of the provider, "+
+					"or set skip_credentials_validation to true. Got error: %s", err),
+			)
+			return
+		}
+		tflog.Debug(ctx, "validated AWS credentials", map[string]any{
+			"account_id": accountID,
+			"partition":  partition,
+		})
+		resp.Diagnostics.Append(validateAccountID(accountID, data.AllowedAccountIds, data.ForbiddenAccountIds)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.DataSourceData = client
+	resp.ResourceData = client
+}
+
+func defaultTagsConfig(data *DefaultTagsModel) *tags.DefaultConfig {
+	config := &tags.DefaultConfig{
+		Tags: make(map[string]string),
+	}
+	if data == nil {
+		return config
+	}
+	for k, v := range data.Tags {
+		config.Tags[k] = v.ValueString()
+	}
+	return config
+}
+
+func ignoreTagsConfig(data *IgnoreTagsModel) *tags.IgnoreConfig {
+	config := &tags.IgnoreConfig{}
+	if data == nil {
+		return config
 	}
-	svc := deadline.NewFromConfig(cfg)
-	resp.DataSourceData = svc
-	resp.ResourceData = svc
+	config.Keys = stringValues(data.Keys)
+	config.KeyPrefixes = stringValues(data.KeyPrefixes)
+	return config
 }
 
 func (p *AWSDeadlineProvider) Resources(ctx context.Context) []func() resource.Resource {
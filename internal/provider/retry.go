@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"time"
+)
+
+const (
+	retryModeStandard = "standard"
+	retryModeAdaptive = "adaptive"
+)
+
+// deadlineRetryables are the Deadline errors that are safe to retry on top of
+// the SDK defaults. Conflicts caused by concurrent changes or by resources
+// that are still transitioning, for example while queue fleet associations are
+// stopping, resolve themselves after a while.
+var deadlineRetryables = []retry.IsErrorRetryable{
+	retry.IsErrorRetryableFunc(func(err error) aws.Ternary {
+		var conflict *dltypes.ConflictException
+		if !errors.As(err, &conflict) {
+			return aws.UnknownTernary
+		}
+		switch conflict.Reason {
+		case dltypes.ConflictExceptionReasonConcurrentModification,
+			dltypes.ConflictExceptionReasonResourceInUse,
+			dltypes.ConflictExceptionReasonStatusConflict:
+			return aws.TrueTernary
+		}
+		return aws.UnknownTernary
+	}),
+}
+
+// retrySettings holds the retry attributes of the provider configuration.
+// Unset attributes keep the SDK defaults.
+type retrySettings struct {
+	// maxRetriesSet tells a max_retries of 0, which disables retries, from an
+	// unset attribute.
+	maxRetriesSet bool
+	maxRetries    int
+	mode          string
+	maxBackoff    time.Duration
+}
+
+func newRetrySettings(data AWSDeadlineProviderModel) (retrySettings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	settings := retrySettings{
+		mode: retryModeStandard,
+	}
+	if !data.MaxRetries.IsNull() {
+		settings.maxRetriesSet = true
+		settings.maxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	if data.RetryMode.ValueString() == retryModeAdaptive {
+		settings.mode = retryModeAdaptive
+	}
+	maxBackoff, d := parseDuration("retry", data.MaxBackoff)
+	diags.Append(d...)
+	settings.maxBackoff = maxBackoff
+	return settings, diags
+}
+
+// retryer returns a retryer constructor honouring the settings. Additional
+// retryables extend the SDK defaults.
+func (s retrySettings) retryer(retryables ...retry.IsErrorRetryable) func() aws.Retryer {
+	standardOptions := func(o *retry.StandardOptions) {
+		if s.maxRetriesSet {
+			o.MaxAttempts = s.maxRetries + 1
+		}
+		if s.maxBackoff > 0 {
+			o.MaxBackoff = s.maxBackoff
+		}
+		o.Retryables = append(o.Retryables, retryables...)
+	}
+	return func() aws.Retryer {
+		if s.mode == retryModeAdaptive {
+			return retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+				o.StandardOptions = append(o.StandardOptions, standardOptions)
+			})
+		}
+		return retry.NewStandard(standardOptions)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRetryerMaxAttempts(t *testing.T) {
+	testCases := map[string]struct {
+		maxRetries types.Int64
+		want       int
+	}{
+		"unset":            {maxRetries: types.Int64Null(), want: retry.DefaultMaxAttempts},
+		"retries disabled": {maxRetries: types.Int64Value(0), want: 1},
+		"five retries":     {maxRetries: types.Int64Value(5), want: 6},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			settings, diags := newRetrySettings(AWSDeadlineProviderModel{MaxRetries: tc.maxRetries})
+			if diags.HasError() {
+				t.Fatalf("newRetrySettings() errors = %v", diags)
+			}
+			if got := settings.retryer()().MaxAttempts(); got != tc.want {
+				t.Errorf("MaxAttempts() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -6,13 +6,19 @@ package associate_member_to_farm
 import (
 	"context"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/deadline"
 	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/conns"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tfresource"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tracing"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/verify"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -20,6 +26,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AssociateMemberToFarmResource{}
 var _ resource.ResourceWithImportState = &AssociateMemberToFarmResource{}
+var _ resource.ResourceWithModifyPlan = &AssociateMemberToFarmResource{}
 
 func New() resource.Resource {
 	return &AssociateMemberToFarmResource{}
@@ -27,11 +34,12 @@ func New() resource.Resource {
 
 // AssociateMemberToFarmResource defines the resource implementation.
 type AssociateMemberToFarmResource struct {
-	client *deadline.Client
+	client *conns.AWSClient
 }
 
 // AssociateMemberToFarmResourceModel describes the resource data model.
 type AssociateMemberToFarmResourceModel struct {
+	Region          types.String `tfsdk:"region"`
 	ID              types.String `tfsdk:"id"`
 	FarmID          types.String `tfsdk:"farm_id"`
 	IdentityStoreID types.String `tfsdk:"identity_store_id"`
@@ -49,10 +57,17 @@ func (r *AssociateMemberToFarmResource) Schema(ctx context.Context, req resource
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Associate Member to Farm resource",
 		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The AWS region of the resource. Defaults to the provider region. Changing it replaces the resource.",
+				Optional:            true,
+				Computed:            true,
+			},
 			"farm_id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the farm to associate the member to",
-				Required:            true,
+				MarkdownDescription: "The ID of the farm to associate the member to. Defaults to the provider `default_farm_id`.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -73,6 +88,9 @@ func (r *AssociateMemberToFarmResource) Schema(ctx context.Context, req resource
 			"principal_type": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The type of principal to associate to the farm. Valid values are `USER` and `GROUP`",
+				Validators: []validator.String{
+					verify.Enum[dltypes.DeadlinePrincipalType](),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -80,6 +98,9 @@ func (r *AssociateMemberToFarmResource) Schema(ctx context.Context, req resource
 			"membership_level": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The membership level of the principal to associate to the farm. Valid values are `VIEWER`, `CONTRIBUTOR`, `OWNER` and `MANAGER`",
+				Validators: []validator.String{
+					verify.Enum[dltypes.MembershipLevel](),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -87,6 +108,9 @@ func (r *AssociateMemberToFarmResource) Schema(ctx context.Context, req resource
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the associate_member_to_farm.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -98,12 +122,12 @@ func (r *AssociateMemberToFarmResource) Configure(ctx context.Context, req resou
 		return
 	}
 
-	client, ok := req.ProviderData.(*deadline.Client)
+	client, ok := req.ProviderData.(*conns.AWSClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *conns.AWSClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -113,6 +137,14 @@ func (r *AssociateMemberToFarmResource) Configure(ctx context.Context, req resou
 }
 
 func (r *AssociateMemberToFarmResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "AssociateMemberToFarmResource.Create")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("create", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data AssociateMemberToFarmResourceModel
 
 	// Read Terraform plan data into the model
@@ -121,6 +153,7 @@ func (r *AssociateMemberToFarmResource) Create(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	request := &deadline.AssociateMemberToFarmInput{
 		FarmId:          data.FarmID.ValueStringPointer(),
 		PrincipalId:     data.PrincipalID.ValueStringPointer(),
@@ -149,18 +182,21 @@ func (r *AssociateMemberToFarmResource) Create(ctx context.Context, req resource
 	////
 	// Does not return the ID of the created resource: https://docs.aws.amazon.com/deadline-cloud/latest/APIReference/API_AssociateMemberToFarm.html#API_AssociateMemberToFarm_RequestSyntax
 	////
-	_, err := r.client.AssociateMemberToFarm(ctx, request)
+	_, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).AssociateMemberToFarm(ctx, request)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create %s, %s, got error: %s", r.typeName(), "association", err))
 		return
 	}
-	data.ID = types.StringValue(fmt.Sprintf("%s-%s-%s", data.FarmID.ValueString(), data.PrincipalID.ValueString(), data.IdentityStoreID.ValueString()))
+	data.ID = types.StringValue(tfresource.CompositeID(data.FarmID.ValueString(), data.PrincipalID.ValueString(), data.IdentityStoreID.ValueString()))
 	tflog.Trace(ctx, fmt.Sprintf("created %s, id: %s", r.typeName(), data.ID.ValueString()))
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AssociateMemberToFarmResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "AssociateMemberToFarmResource.Read")
+	defer tracing.End(span, &resp.Diagnostics)
+
 	var data AssociateMemberToFarmResourceModel
 
 	// Read Terraform prior state data into the model
@@ -169,10 +205,39 @@ func (r *AssociateMemberToFarmResource) Read(ctx context.Context, req resource.R
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
+	// State written before the region attribute existed has no region.
+	if data.Region.IsNull() {
+		data.Region = types.StringValue(r.client.Region)
+	}
+	member, err := r.findMember(ctx, data)
+	if err != nil && !tfresource.NotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s, got error: %s", r.typeName(), err))
+		return
+	}
+	if member == nil {
+		tflog.Warn(ctx, fmt.Sprintf("%s not found, removing it from the state", r.typeName()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.IdentityStoreID = types.StringPointerValue(member.IdentityStoreId)
+	data.PrincipalType = types.StringValue(string(member.PrincipalType))
+	data.MembershipLevel = types.StringValue(string(member.MembershipLevel))
+	// State written before composite IDs used a separator that does not
+	// clash with Deadline IDs has an ID joined with hyphens.
+	data.ID = types.StringValue(tfresource.CompositeID(data.FarmID.ValueString(), data.PrincipalID.ValueString(), data.IdentityStoreID.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AssociateMemberToFarmResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "AssociateMemberToFarmResource.Update")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("update", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data AssociateMemberToFarmResourceModel
 
 	// Read Terraform plan data into the model
@@ -181,11 +246,20 @@ func (r *AssociateMemberToFarmResource) Update(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AssociateMemberToFarmResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "AssociateMemberToFarmResource.Delete")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("delete", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data AssociateMemberToFarmResourceModel
 
 	// Read Terraform prior state data into the model
@@ -194,21 +268,64 @@ func (r *AssociateMemberToFarmResource) Delete(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	request := &deadline.DisassociateMemberFromFarmInput{
-		FarmId:      data.ID.ValueStringPointer(),
+		FarmId:      data.FarmID.ValueStringPointer(),
 		PrincipalId: data.PrincipalID.ValueStringPointer(),
 	}
-	_, err := r.client.DisassociateMemberFromFarm(ctx, request)
-	if err != nil {
+	_, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).DisassociateMemberFromFarm(ctx, request)
+	// A resource that is already gone is deleted.
+	if err != nil && !tfresource.NotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", r.typeName(), err))
 		return
 	}
 }
 
+func (r *AssociateMemberToFarmResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		return
+	}
+	r.client.ModifyPlanRegion(ctx, req, resp)
+	r.client.ModifyPlanFarmID(ctx, req, resp)
+}
+
 func (r *AssociateMemberToFarmResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	tfresource.ImportCompositeID(ctx, req, resp, "farm_id", "principal_id", "identity_store_id")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 }
 
 func (r *AssociateMemberToFarmResource) typeName() string {
 	return "deadline_associate_member_to_farm"
 }
+
+// logFields sets the identifiers of the association as fields of the log lines.
+func (r *AssociateMemberToFarmResource) logFields(ctx context.Context, data AssociateMemberToFarmResourceModel) context.Context {
+	return conns.LogFields(ctx, map[string]types.String{
+		"farm_id":      data.FarmID,
+		"principal_id": data.PrincipalID,
+	})
+}
+
+// findMember returns the membership of the principal, or nil when the
+// principal is not a member of the farm.
+func (r *AssociateMemberToFarmResource) findMember(ctx context.Context, data AssociateMemberToFarmResourceModel) (*dltypes.FarmMember, error) {
+	paginator := deadline.NewListFarmMembersPaginator(r.client.DeadlineClientForRegion(data.Region.ValueString()), &deadline.ListFarmMembersInput{
+		FarmId: data.FarmID.ValueStringPointer(),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range page.Members {
+			if aws.ToString(member.PrincipalId) == data.PrincipalID.ValueString() {
+				return &member, nil
+			}
+		}
+	}
+	return nil, nil
+}
@@ -6,13 +6,19 @@ package associate_member_to_fleet
 import (
 	"context"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/deadline"
 	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/conns"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tfresource"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tracing"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/verify"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -20,6 +26,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AssociateMemberToFleetResource{}
 var _ resource.ResourceWithImportState = &AssociateMemberToFleetResource{}
+var _ resource.ResourceWithModifyPlan = &AssociateMemberToFleetResource{}
 
 func New() resource.Resource {
 	return &AssociateMemberToFleetResource{}
@@ -27,11 +34,12 @@ func New() resource.Resource {
 
 // AssociateMemberToFleetResource defines the resource implementation.
 type AssociateMemberToFleetResource struct {
-	client *deadline.Client
+	client *conns.AWSClient
 }
 
 // AssociateMemberToFleetResourceModel describes the resource data model.
 type AssociateMemberToFleetResourceModel struct {
+	Region          types.String `tfsdk:"region"`
 	ID              types.String `tfsdk:"id"`
 	FarmID          types.String `tfsdk:"farm_id"`
 	FleetID         types.String `tfsdk:"fleet_id"`
@@ -50,10 +58,17 @@ func (r *AssociateMemberToFleetResource) Schema(ctx context.Context, req resourc
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Associate Member to fleet resource",
 		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The AWS region of the resource. Defaults to the provider region. Changing it replaces the resource.",
+				Optional:            true,
+				Computed:            true,
+			},
 			"farm_id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the farm to associate the member to",
-				Required:            true,
+				MarkdownDescription: "The ID of the farm to associate the member to. Defaults to the provider `default_farm_id`.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -81,6 +96,9 @@ func (r *AssociateMemberToFleetResource) Schema(ctx context.Context, req resourc
 			"principal_type": schema.StringAttribute{
 				MarkdownDescription: "The type of principal to associate to the fleet. Valid values are `USER` and `GROUP`",
 				Required:            true,
+				Validators: []validator.String{
+					verify.Enum[dltypes.DeadlinePrincipalType](),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -88,10 +106,16 @@ func (r *AssociateMemberToFleetResource) Schema(ctx context.Context, req resourc
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the associate_member_to_fleet.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"membership_level": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The membership level of the principal to associate to the farm. Valid values are `VIEWER`, `CONTRIBUTOR`, `OWNER` and `MANAGER`",
+				Validators: []validator.String{
+					verify.Enum[dltypes.MembershipLevel](),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -106,12 +130,12 @@ func (r *AssociateMemberToFleetResource) Configure(ctx context.Context, req reso
 		return
 	}
 
-	client, ok := req.ProviderData.(*deadline.Client)
+	client, ok := req.ProviderData.(*conns.AWSClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *conns.AWSClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -121,6 +145,14 @@ func (r *AssociateMemberToFleetResource) Configure(ctx context.Context, req reso
 }
 
 func (r *AssociateMemberToFleetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "AssociateMemberToFleetResource.Create")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("create", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data AssociateMemberToFleetResourceModel
 
 	// Read Terraform plan data into the model
@@ -129,6 +161,7 @@ func (r *AssociateMemberToFleetResource) Create(ctx context.Context, req resourc
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	request := &deadline.AssociateMemberToFleetInput{
 		FarmId:          data.FarmID.ValueStringPointer(),
 		FleetId:         data.FleetID.ValueStringPointer(),
@@ -158,18 +191,21 @@ func (r *AssociateMemberToFleetResource) Create(ctx context.Context, req resourc
 	////
 	// Does not return the ID of the created resource: https://docs.aws.amazon.com/deadline-cloud/latest/APIReference/API_AssociateMemberToFarm.html#API_AssociateMemberToFarm_RequestSyntax
 	////
-	_, err := r.client.AssociateMemberToFleet(ctx, request)
+	_, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).AssociateMemberToFleet(ctx, request)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create %s, %s, got error: %s", r.typeName(), "association", err))
 		return
 	}
-	data.ID = types.StringValue(fmt.Sprintf("%s-%s-%s", data.FarmID.ValueString(), data.PrincipalID.ValueString(), data.IdentityStoreID.ValueString()))
+	data.ID = types.StringValue(tfresource.CompositeID(data.FarmID.ValueString(), data.FleetID.ValueString(), data.PrincipalID.ValueString(), data.IdentityStoreID.ValueString()))
 	tflog.Trace(ctx, fmt.Sprintf("created %s, id: %s", r.typeName(), data.ID.ValueString()))
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AssociateMemberToFleetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "AssociateMemberToFleetResource.Read")
+	defer tracing.End(span, &resp.Diagnostics)
+
 	var data AssociateMemberToFleetResourceModel
 
 	// Read Terraform prior state data into the model
@@ -178,10 +214,39 @@ func (r *AssociateMemberToFleetResource) Read(ctx context.Context, req resource.
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
+	// State written before the region attribute existed has no region.
+	if data.Region.IsNull() {
+		data.Region = types.StringValue(r.client.Region)
+	}
+	member, err := r.findMember(ctx, data)
+	if err != nil && !tfresource.NotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s, got error: %s", r.typeName(), err))
+		return
+	}
+	if member == nil {
+		tflog.Warn(ctx, fmt.Sprintf("%s not found, removing it from the state", r.typeName()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.IdentityStoreID = types.StringPointerValue(member.IdentityStoreId)
+	data.PrincipalType = types.StringValue(string(member.PrincipalType))
+	data.MemberShipLevel = types.StringValue(string(member.MembershipLevel))
+	// State written before composite IDs used a separator that does not
+	// clash with Deadline IDs has an ID joined with hyphens, without the fleet.
+	data.ID = types.StringValue(tfresource.CompositeID(data.FarmID.ValueString(), data.FleetID.ValueString(), data.PrincipalID.ValueString(), data.IdentityStoreID.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AssociateMemberToFleetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "AssociateMemberToFleetResource.Update")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("update", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data AssociateMemberToFleetResourceModel
 
 	// Read Terraform plan data into the model
@@ -190,11 +255,20 @@ func (r *AssociateMemberToFleetResource) Update(ctx context.Context, req resourc
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AssociateMemberToFleetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "AssociateMemberToFleetResource.Delete")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("delete", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data AssociateMemberToFleetResourceModel
 
 	// Read Terraform prior state data into the model
@@ -203,22 +277,67 @@ func (r *AssociateMemberToFleetResource) Delete(ctx context.Context, req resourc
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	request := &deadline.DisassociateMemberFromFleetInput{
 		FarmId:      data.FarmID.ValueStringPointer(),
 		FleetId:     data.FleetID.ValueStringPointer(),
 		PrincipalId: data.PrincipalID.ValueStringPointer(),
 	}
-	_, err := r.client.DisassociateMemberFromFleet(ctx, request)
-	if err != nil {
+	_, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).DisassociateMemberFromFleet(ctx, request)
+	// A resource that is already gone is deleted.
+	if err != nil && !tfresource.NotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", r.typeName(), err))
 		return
 	}
 }
 
+func (r *AssociateMemberToFleetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		return
+	}
+	r.client.ModifyPlanRegion(ctx, req, resp)
+	r.client.ModifyPlanFarmID(ctx, req, resp)
+}
+
 func (r *AssociateMemberToFleetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	tfresource.ImportCompositeID(ctx, req, resp, "farm_id", "fleet_id", "principal_id", "identity_store_id")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 }
 
 func (r *AssociateMemberToFleetResource) typeName() string {
 	return "deadline_associate_member_to_fleet"
 }
+
+// logFields sets the identifiers of the association as fields of the log lines.
+func (r *AssociateMemberToFleetResource) logFields(ctx context.Context, data AssociateMemberToFleetResourceModel) context.Context {
+	return conns.LogFields(ctx, map[string]types.String{
+		"farm_id":      data.FarmID,
+		"fleet_id":     data.FleetID,
+		"principal_id": data.PrincipalID,
+	})
+}
+
+// findMember returns the membership of the principal, or nil when the
+// principal is not a member of the fleet.
+func (r *AssociateMemberToFleetResource) findMember(ctx context.Context, data AssociateMemberToFleetResourceModel) (*dltypes.FleetMember, error) {
+	paginator := deadline.NewListFleetMembersPaginator(r.client.DeadlineClientForRegion(data.Region.ValueString()), &deadline.ListFleetMembersInput{
+		FarmId:  data.FarmID.ValueStringPointer(),
+		FleetId: data.FleetID.ValueStringPointer(),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range page.Members {
+			if aws.ToString(member.PrincipalId) == data.PrincipalID.ValueString() {
+				return &member, nil
+			}
+		}
+	}
+	return nil, nil
+}
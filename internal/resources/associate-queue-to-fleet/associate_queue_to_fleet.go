@@ -7,17 +7,25 @@ import (
 	"context"
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/service/deadline"
+	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/conns"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tfresource"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tracing"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"strings"
+	"time"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AssociateQueueToFleetResource{}
 var _ resource.ResourceWithImportState = &AssociateQueueToFleetResource{}
+var _ resource.ResourceWithModifyPlan = &AssociateQueueToFleetResource{}
 
 func New() resource.Resource {
 	return &AssociateQueueToFleetResource{}
@@ -25,15 +33,17 @@ func New() resource.Resource {
 
 // AssociateQueueToFleetResource defines the resource implementation.
 type AssociateQueueToFleetResource struct {
-	client *deadline.Client
+	client *conns.AWSClient
 }
 
 // AssociateQueueToFleetResourceModel describes the resource data model.
 type AssociateQueueToFleetResourceModel struct {
-	ID      types.String `tfsdk:"id"`
-	FarmID  types.String `tfsdk:"farm_id"`
-	FleetID types.String `tfsdk:"fleet_id"`
-	QueueID types.String `tfsdk:"queue_id"`
+	Region   types.String   `tfsdk:"region"`
+	ID       types.String   `tfsdk:"id"`
+	FarmID   types.String   `tfsdk:"farm_id"`
+	FleetID  types.String   `tfsdk:"fleet_id"`
+	QueueID  types.String   `tfsdk:"queue_id"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *AssociateQueueToFleetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -44,22 +54,47 @@ func (r *AssociateQueueToFleetResource) Schema(ctx context.Context, req resource
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Associate Member to fleet resource",
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
 		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The AWS region of the resource. Defaults to the provider region. Changing it replaces the resource.",
+				Optional:            true,
+				Computed:            true,
+			},
 			"queue_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the farm to associate the member to",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"fleet_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the fleet to associate the member to",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"farm_id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the farm to associate the member to",
-				Required:            true,
+				MarkdownDescription: "The ID of the farm to associate the member to. Defaults to the provider `default_farm_id`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the associate_queue_to_fleet.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -71,12 +106,12 @@ func (r *AssociateQueueToFleetResource) Configure(ctx context.Context, req resou
 		return
 	}
 
-	client, ok := req.ProviderData.(*deadline.Client)
+	client, ok := req.ProviderData.(*conns.AWSClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *conns.AWSClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -86,6 +121,14 @@ func (r *AssociateQueueToFleetResource) Configure(ctx context.Context, req resou
 }
 
 func (r *AssociateQueueToFleetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "AssociateQueueToFleetResource.Create")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("create", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data AssociateQueueToFleetResourceModel
 
 	// Read Terraform plan data into the model
@@ -94,6 +137,7 @@ func (r *AssociateQueueToFleetResource) Create(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	request := &deadline.CreateQueueFleetAssociationInput{
 		FarmId:  data.FarmID.ValueStringPointer(),
 		FleetId: data.FleetID.ValueStringPointer(),
@@ -102,60 +146,84 @@ func (r *AssociateQueueToFleetResource) Create(ctx context.Context, req resource
 	////
 	// Does not return the ID of the created resource: https://docs.aws.amazon.com/deadline-cloud/latest/APIReference/API_AssociateMemberToFarm.html#API_AssociateMemberToFarm_RequestSyntax
 	////
-	_, err := r.client.CreateQueueFleetAssociation(ctx, request)
+	conn := r.client.DeadlineClientForRegion(data.Region.ValueString())
+	_, err := conn.CreateQueueFleetAssociation(ctx, request)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create %s, %s, got error: %s", r.typeName(), "association", err))
+		return
+	}
+	data.ID = types.StringValue(tfresource.CompositeID(data.FarmID.ValueString(), data.QueueID.ValueString(), data.FleetID.ValueString()))
+	createTimeout, diags := data.Timeouts.Create(ctx, associationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pending := []string{
+		string(dltypes.QueueFleetAssociationStatusStopSchedulingAndCompleteTasks),
+		string(dltypes.QueueFleetAssociationStatusStopSchedulingAndCancelTasks),
+	}
+	target := []string{string(dltypes.QueueFleetAssociationStatusActive)}
+	err = tfresource.WaitForStatus(ctx, createTimeout, pending, target, statusQueueFleetAssociation(conn, data))
 	if err != nil {
+		// Keep the association in the state so that it is replaced or deleted by the next apply.
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create %s, %s, got error: %s", r.typeName(), "association", err))
 		return
 	}
-	data.ID = types.StringValue(fmt.Sprintf("%s-%s-%s", data.FarmID.ValueString(), data.FleetID.ValueString(), data.QueueID.ValueString()))
 	tflog.Trace(ctx, fmt.Sprintf("created %s, id: %s", r.typeName(), data.ID.ValueString()))
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AssociateQueueToFleetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "AssociateQueueToFleetResource.Read")
+	defer tracing.End(span, &resp.Diagnostics)
+
 	var data AssociateQueueToFleetResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	idArray := strings.Split(data.ID.String(), "-")
-	farmID := data.FarmID.ValueStringPointer()
-	if farmID == nil {
-		farmID = &idArray[0]
-	}
-	fleetID := data.FleetID.ValueStringPointer()
-	if fleetID == nil {
-		fleetID = &idArray[1]
-	}
-	queueID := data.QueueID.ValueStringPointer()
-	if queueID == nil {
-		queueID = &idArray[2]
-	}
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	request := &deadline.GetQueueFleetAssociationInput{
 		FleetId: data.FleetID.ValueStringPointer(),
 		FarmId:  data.FarmID.ValueStringPointer(),
 		QueueId: data.QueueID.ValueStringPointer(),
 	}
 
-	_, err := r.client.GetQueueFleetAssociation(ctx, request)
+	_, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).GetQueueFleetAssociation(ctx, request)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to get Queue Fleet Association", "")
-	}
-
-	if resp.Diagnostics.HasError() {
+		if tfresource.NotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("%s not found, removing it from the state", r.typeName()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s, got error: %s", r.typeName(), err))
 		return
 	}
-	data.FarmID = types.StringValue(*farmID)
-	data.QueueID = types.StringValue(*queueID)
-	data.FleetID = types.StringValue(*fleetID)
-
-	data.ID = types.StringValue(fmt.Sprintf("%s-%s-%s", data.FarmID.ValueString(), data.FleetID.ValueString(), data.QueueID.ValueString()))
+	ctx = r.logFields(ctx, data)
+	// State written before the region attribute existed has no region.
+	if data.Region.IsNull() {
+		data.Region = types.StringValue(r.client.Region)
+	}
+	// State written before composite IDs used a separator that does not
+	// clash with Deadline IDs has an ID joined with hyphens.
+	data.ID = types.StringValue(tfresource.CompositeID(data.FarmID.ValueString(), data.QueueID.ValueString(), data.FleetID.ValueString()))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AssociateQueueToFleetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "AssociateQueueToFleetResource.Update")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("update", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data AssociateQueueToFleetResourceModel
 
 	// Read Terraform plan data into the model
@@ -164,23 +232,24 @@ func (r *AssociateQueueToFleetResource) Update(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	request := &deadline.UpdateQueueFleetAssociationInput{
-		QueueId: data.QueueID.ValueStringPointer(),
-		FarmId:  data.FarmID.ValueStringPointer(),
-		FleetId: data.FleetID.ValueStringPointer(),
-	}
-	_, err := r.client.UpdateQueueFleetAssociation(ctx, request)
-
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to update queue fleet association", "")
-	}
-	data.ID = types.StringValue(fmt.Sprintf("%s-%s-%s", data.FarmID.ValueString(), data.FleetID.ValueString(), data.QueueID.ValueString()))
+	ctx = r.logFields(ctx, data)
+	// Every attribute but timeouts replaces the association, so there is
+	// nothing to send to Deadline.
+	data.ID = types.StringValue(tfresource.CompositeID(data.FarmID.ValueString(), data.QueueID.ValueString(), data.FleetID.ValueString()))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AssociateQueueToFleetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "AssociateQueueToFleetResource.Delete")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("delete", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data AssociateQueueToFleetResourceModel
 
 	// Read Terraform prior state data into the model
@@ -188,22 +257,98 @@ func (r *AssociateQueueToFleetResource) Delete(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	request := &deadline.DeleteQueueFleetAssociationInput{
+	ctx = r.logFields(ctx, data)
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, associationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// An association has to be stopped before it can be deleted. The tasks
+	// that are running complete, so destroying an association does not
+	// cancel renders in flight.
+	conn := r.client.DeadlineClientForRegion(data.Region.ValueString())
+	_, err := conn.UpdateQueueFleetAssociation(ctx, &deadline.UpdateQueueFleetAssociationInput{
 		FarmId:  data.FarmID.ValueStringPointer(),
 		FleetId: data.FleetID.ValueStringPointer(),
 		QueueId: data.QueueID.ValueStringPointer(),
+		Status:  dltypes.UpdateQueueFleetAssociationStatusStopSchedulingAndCompleteTasks,
+	})
+	if tfresource.NotFound(err) {
+		// A resource that is already gone is deleted.
+		return
 	}
-	_, err := r.client.DeleteQueueFleetAssociation(ctx, request)
 	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to stop %s, got error: %s", r.typeName(), err))
+		return
+	}
+	pending := []string{
+		string(dltypes.QueueFleetAssociationStatusStopSchedulingAndCompleteTasks),
+		string(dltypes.QueueFleetAssociationStatusStopSchedulingAndCancelTasks),
+	}
+	target := []string{string(dltypes.QueueFleetAssociationStatusStopped)}
+	err = tfresource.WaitForStatus(ctx, deleteTimeout, pending, target, statusQueueFleetAssociation(conn, data))
+	if err != nil && !tfresource.NotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to stop %s, got error: %s", r.typeName(), err))
+		return
+	}
+	request := &deadline.DeleteQueueFleetAssociationInput{
+		FarmId:  data.FarmID.ValueStringPointer(),
+		FleetId: data.FleetID.ValueStringPointer(),
+		QueueId: data.QueueID.ValueStringPointer(),
+	}
+	_, err = conn.DeleteQueueFleetAssociation(ctx, request)
+	// A resource that is already gone is deleted.
+	if err != nil && !tfresource.NotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", r.typeName(), err))
 		return
 	}
 }
 
+func (r *AssociateQueueToFleetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		return
+	}
+	r.client.ModifyPlanRegion(ctx, req, resp)
+	r.client.ModifyPlanFarmID(ctx, req, resp)
+}
+
 func (r *AssociateQueueToFleetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	tfresource.ImportCompositeID(ctx, req, resp, "farm_id", "queue_id", "fleet_id")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 }
 
 func (r *AssociateQueueToFleetResource) typeName() string {
 	return "deadline_associate_queue_to_fleet"
 }
+
+// logFields sets the identifiers of the association as fields of the log lines.
+func (r *AssociateQueueToFleetResource) logFields(ctx context.Context, data AssociateQueueToFleetResourceModel) context.Context {
+	return conns.LogFields(ctx, map[string]types.String{
+		"farm_id":  data.FarmID,
+		"fleet_id": data.FleetID,
+		"queue_id": data.QueueID,
+	})
+}
+
+// associationTimeout is the default time to wait for an association to settle.
+const associationTimeout = 30 * time.Minute
+
+// statusQueueFleetAssociation reports the status of the association. Deadline
+// does not return a status message for associations.
+func statusQueueFleetAssociation(conn *deadline.Client, data AssociateQueueToFleetResourceModel) tfresource.StatusFunc {
+	return func(ctx context.Context) (string, string, error) {
+		association, err := conn.GetQueueFleetAssociation(ctx, &deadline.GetQueueFleetAssociationInput{
+			FarmId:  data.FarmID.ValueStringPointer(),
+			FleetId: data.FleetID.ValueStringPointer(),
+			QueueId: data.QueueID.ValueStringPointer(),
+		})
+		if err != nil {
+			return "", "", err
+		}
+		return string(association.Status), "", nil
+	}
+}
@@ -6,10 +6,20 @@ package farm
 import (
 	"context"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/deadline"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/conns"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tags"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tfresource"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tracing"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/verify"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -17,6 +27,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &FarmResource{}
 var _ resource.ResourceWithImportState = &FarmResource{}
+var _ resource.ResourceWithModifyPlan = &FarmResource{}
 
 func New() resource.Resource {
 	return &FarmResource{}
@@ -24,14 +35,19 @@ func New() resource.Resource {
 
 // FarmResource defines the resource implementation.
 type FarmResource struct {
-	client *deadline.Client
+	client *conns.AWSClient
 }
 
 // FarmResourceModel describes the resource data model.
 type FarmResourceModel struct {
+	Arn         types.String `tfsdk:"arn"`
+	Region      types.String `tfsdk:"region"`
 	DisplayName types.String `tfsdk:"display_name"`
 	Description types.String `tfsdk:"description"`
+	KmsKeyArn   types.String `tfsdk:"kms_key_arn"`
 	ID          types.String `tfsdk:"id"`
+	Tags        types.Map    `tfsdk:"tags"`
+	TagsAll     types.Map    `tfsdk:"tags_all"`
 }
 
 func (r *FarmResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -43,6 +59,18 @@ func (r *FarmResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Farm resource",
 		Attributes: map[string]schema.Attribute{
+			"arn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ARN of the farm.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The AWS region of the resource. Defaults to the provider region. Changing it replaces the resource.",
+				Optional:            true,
+				Computed:            true,
+			},
 			"display_name": schema.StringAttribute{
 				MarkdownDescription: "The display name of the farm.",
 				Required:            true,
@@ -51,9 +79,32 @@ func (r *FarmResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "The description of the farm.",
 				Optional:            true,
 			},
+			"kms_key_arn": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the KMS key used to encrypt the farm data. Changing it replaces the farm.",
+				Optional:            true,
+				Validators: []validator.String{
+					verify.ARN("kms"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the farm.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tags": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "The tags to apply to the farm.",
+			},
+			"tags_all": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "The tags of the farm, including the provider default tags.",
 			},
 		},
 	}
@@ -65,12 +116,12 @@ func (r *FarmResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*deadline.Client)
+	client, ok := req.ProviderData.(*conns.AWSClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *conns.AWSClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -80,6 +131,14 @@ func (r *FarmResource) Configure(ctx context.Context, req resource.ConfigureRequ
 }
 
 func (r *FarmResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "FarmResource.Create")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("create", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data FarmResourceModel
 
 	// Read Terraform plan data into the model
@@ -88,22 +147,43 @@ func (r *FarmResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
+	resourceTags, diags := tags.FromValue(ctx, data.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	allTags := r.client.IgnoreTagsConfig.Filter(r.client.DefaultTagsConfig.MergeTags(resourceTags))
 	farmRequest := deadline.CreateFarmInput{
 		DisplayName: data.DisplayName.ValueStringPointer(),
 		Description: data.Description.ValueStringPointer(),
+		KmsKeyArn:   data.KmsKeyArn.ValueStringPointer(),
+		Tags:        allTags,
 	}
-	farmOutput, err := r.client.CreateFarm(ctx, &farmRequest)
+	farmOutput, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).CreateFarm(ctx, &farmRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create %s, %s, got error: %s", r.typeName(), data.DisplayName.String(), err))
 		return
 	}
 	data.ID = types.StringValue(*farmOutput.FarmId)
+	ctx = r.logFields(ctx, data)
+	farmARN, err := r.client.DeadlineARN(ctx, data.Region.ValueString(), "farm/"+data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s ARN, got error: %s", r.typeName(), err))
+		return
+	}
+	data.Arn = types.StringValue(farmARN)
+	data.TagsAll, diags = tags.ToValue(ctx, allTags)
+	resp.Diagnostics.Append(diags...)
 	tflog.Trace(ctx, fmt.Sprintf("created %s", r.typeName()))
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *FarmResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "FarmResource.Read")
+	defer tracing.End(span, &resp.Diagnostics)
+
 	var data FarmResourceModel
 
 	// Read Terraform prior state data into the model
@@ -112,38 +192,87 @@ func (r *FarmResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	farmResponse, err := r.client.GetFarm(ctx, &deadline.GetFarmInput{
+	ctx = r.logFields(ctx, data)
+	// State written before the region attribute existed has no region.
+	if data.Region.IsNull() {
+		data.Region = types.StringValue(r.client.Region)
+	}
+	conn := r.client.DeadlineClientForRegion(data.Region.ValueString())
+	farmResponse, err := conn.GetFarm(ctx, &deadline.GetFarmInput{
 		FarmId: data.ID.ValueStringPointer(),
 	})
 	if err != nil {
+		if tfresource.NotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("%s not found, removing it from the state", r.typeName()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s, got error: %s", r.typeName(), err))
 		return
 	}
 	data.Description = types.StringValue(*farmResponse.Description)
 	data.DisplayName = types.StringValue(*farmResponse.DisplayName)
+	data.KmsKeyArn = types.StringPointerValue(farmResponse.KmsKeyArn)
+	farmARN, err := r.client.DeadlineARN(ctx, data.Region.ValueString(), "farm/"+data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s ARN, got error: %s", r.typeName(), err))
+		return
+	}
+	data.Arn = types.StringValue(farmARN)
+	remoteTags, err := tags.List(ctx, conn, farmARN)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s tags, got error: %s", r.typeName(), err))
+		return
+	}
+	var diags diag.Diagnostics
+	data.Tags, data.TagsAll, diags = tags.Flatten(ctx, r.client.DefaultTagsConfig, r.client.IgnoreTagsConfig, data.Tags, remoteTags)
+	resp.Diagnostics.Append(diags...)
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *FarmResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data FarmResourceModel
+	ctx, span := tracing.Start(ctx, "FarmResource.Update")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("update", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data, state FarmResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
+	data.Arn = state.Arn
 	updateRequest := deadline.UpdateFarmInput{
 		FarmId:      data.ID.ValueStringPointer(),
 		Description: data.Description.ValueStringPointer(),
 		DisplayName: data.DisplayName.ValueStringPointer(),
 	}
-	_, err := r.client.UpdateFarm(ctx, &updateRequest)
+	conn := r.client.DeadlineClientForRegion(data.Region.ValueString())
+	_, err := conn.UpdateFarm(ctx, &updateRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update %s, got error: %s", r.typeName(), err))
 		return
 	}
+	if !data.TagsAll.Equal(state.TagsAll) {
+		farmARN, err := r.client.DeadlineARN(ctx, data.Region.ValueString(), "farm/"+data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s ARN, got error: %s", r.typeName(), err))
+			return
+		}
+		resp.Diagnostics.Append(tags.UpdateValues(ctx, conn, r.client.IgnoreTagsConfig, farmARN, state.TagsAll, data.TagsAll)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 	data.Description = types.StringValue(*updateRequest.Description)
 	data.DisplayName = types.StringValue(*updateRequest.DisplayName)
 	// Save updated data into Terraform state
@@ -151,6 +280,14 @@ func (r *FarmResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *FarmResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "FarmResource.Delete")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("delete", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data FarmResourceModel
 
 	// Read Terraform prior state data into the model
@@ -159,20 +296,62 @@ func (r *FarmResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	deleteResourceRequest := &deadline.DeleteFarmInput{
 		FarmId: data.ID.ValueStringPointer(),
 	}
-	_, err := r.client.DeleteFarm(ctx, deleteResourceRequest)
-	if err != nil {
+	_, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).DeleteFarm(ctx, deleteResourceRequest)
+	// A resource that is already gone is deleted.
+	if err != nil && !tfresource.NotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", r.typeName(), err))
 		return
 	}
 }
 
+func (r *FarmResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		return
+	}
+	r.client.ModifyPlanRegion(ctx, req, resp)
+	tags.ModifyPlan(ctx, r.client.DefaultTagsConfig, r.client.IgnoreTagsConfig, req, resp)
+}
+
 func (r *FarmResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	tfresource.ImportCompositeIDOrName(ctx, req, resp, r.idsByName, "id")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Imported resources, including the ones found by display name, are
+	// looked up in the provider region.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("region"), r.client.Region)...)
 }
 
 func (r *FarmResource) typeName() string {
 	return "deadline_farm"
 }
+
+// logFields sets the identifiers of the farm as fields of the log lines.
+func (r *FarmResource) logFields(ctx context.Context, data FarmResourceModel) context.Context {
+	return conns.LogFields(ctx, map[string]types.String{
+		"farm_id": data.ID,
+	})
+}
+
+// idsByName returns the IDs of the farms named name in the provider region.
+func (r *FarmResource) idsByName(ctx context.Context, parents []string, name string) ([]string, error) {
+	var ids []string
+	paginator := deadline.NewListFarmsPaginator(r.client.DeadlineClient, &deadline.ListFarmsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, farm := range page.Farms {
+			if aws.ToString(farm.DisplayName) == name {
+				ids = append(ids, aws.ToString(farm.FarmId))
+			}
+		}
+	}
+	return ids, nil
+}
@@ -6,20 +6,33 @@ package fleet
 import (
 	"context"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/deadline"
 	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/conns"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tags"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tfresource"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tracing"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/verify"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"strings"
+	"time"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &FleetResource{}
 var _ resource.ResourceWithImportState = &FleetResource{}
+var _ resource.ResourceWithModifyPlan = &FleetResource{}
 
 func New() resource.Resource {
 	return &FleetResource{}
@@ -27,7 +40,7 @@ func New() resource.Resource {
 
 // FleetResource defines the resource implementation.
 type FleetResource struct {
-	client *deadline.Client
+	client *conns.AWSClient
 }
 
 type FleetResourceConfigurationModel struct {
@@ -58,12 +71,19 @@ type FleetResourceEc2InstanceCapabilitiesRootEBSVolumeModel struct {
 }
 
 type FleetResourceEc2InstanceCapabilitiesAcceleratorCapabilitiesModel struct {
-	Selections types.ListType `tfsdk:"selections"`
-	Count      types.Int32    `tfsdk:"count"`
+	Selections []FleetResourceAcceleratorSelectionModel `tfsdk:"selections"`
+	Count      types.Int32                              `tfsdk:"count"`
+}
+
+type FleetResourceAcceleratorSelectionModel struct {
+	Name    types.String `tfsdk:"name"`
+	Runtime types.String `tfsdk:"runtime"`
 }
 
 // FleetResourceModel describes the resource data model.
 type FleetResourceModel struct {
+	Arn            types.String                     `tfsdk:"arn"`
+	Region         types.String                     `tfsdk:"region"`
 	DisplayName    types.String                     `tfsdk:"display_name"`
 	Description    types.String                     `tfsdk:"description"`
 	FarmId         types.String                     `tfsdk:"farm_id"`
@@ -72,6 +92,9 @@ type FleetResourceModel struct {
 	RoleArn        types.String                     `tfsdk:"role_arn"`
 	ID             types.String                     `tfsdk:"id"`
 	Configuration  *FleetResourceConfigurationModel `tfsdk:"configuration"`
+	Tags           types.Map                        `tfsdk:"tags"`
+	TagsAll        types.Map                        `tfsdk:"tags_all"`
+	Timeouts       timeouts.Value                   `tfsdk:"timeouts"`
 }
 
 func (r *FleetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -82,6 +105,11 @@ func (r *FleetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Fleet resource",
 		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 			"configuration": schema.SingleNestedBlock{
 				Blocks: map[string]schema.Block{
 					"ec2_instance_capabilities": schema.SingleNestedBlock{
@@ -92,9 +120,16 @@ func (r *FleetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 									"selections": schema.ListNestedAttribute{
 										NestedObject: schema.NestedAttributeObject{
 											Attributes: map[string]schema.Attribute{
-												"name": schema.StringAttribute{Required: true},
+												"name": schema.StringAttribute{
+													Required:    true,
+													Description: "The name of the GPU accelerator, for example `t4`.",
+													Validators: []validator.String{
+														verify.Enum[dltypes.AcceleratorName](),
+													},
+												},
 												"runtime": schema.StringAttribute{
-													Optional: true,
+													Optional:    true,
+													Description: "The driver version that the GPU accelerator uses.",
 												},
 											},
 										},
@@ -138,12 +173,18 @@ func (r *FleetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Attributes: map[string]schema.Attribute{
 							"cpu_architecture": schema.StringAttribute{
 								Optional: true,
+								Validators: []validator.String{
+									verify.Enum[dltypes.CpuArchitectureType](),
+								},
 							},
 							"min_cpu_count": schema.Int32Attribute{Optional: true},
 							"max_cpu_count": schema.Int32Attribute{Optional: true},
 
 							"os_family": schema.StringAttribute{
 								Optional: true,
+								Validators: []validator.String{
+									osFamilyValidator{},
+								},
 							},
 							"allowed_instance_types": schema.ListAttribute{
 								ElementType: types.StringType,
@@ -160,15 +201,33 @@ func (r *FleetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					"mode": schema.StringAttribute{
 						Optional:    true,
 						Description: "The mode of the fleet configuration. It can either be 'aws_managed' or 'customer_managed'.",
+						Validators: []validator.String{
+							stringvalidator.OneOf("aws_managed", "customer_managed"),
+						},
 					},
 					"ec2_market_type": schema.StringAttribute{
 						Optional:    true,
 						Description: "The market type of the EC2 instance. It can either be 'spot' or 'on-demand'. Only required when the mode is 'aws_managed'.",
+						Validators: []validator.String{
+							verify.Enum[dltypes.Ec2MarketType](),
+						},
 					},
 				},
 			},
 		},
 		Attributes: map[string]schema.Attribute{
+			"arn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ARN of the fleet.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The AWS region of the resource. Defaults to the provider region. Changing it replaces the resource.",
+				Optional:            true,
+				Computed:            true,
+			},
 			"display_name": schema.StringAttribute{
 				MarkdownDescription: "The display name of the fleet.",
 				Required:            true,
@@ -176,6 +235,9 @@ func (r *FleetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"role_arn": schema.StringAttribute{
 				MarkdownDescription: "The ARN of the role that the fleet assumes.",
 				Required:            true,
+				Validators: []validator.String{
+					verify.ARN("iam"),
+				},
 			},
 			"description": schema.StringAttribute{
 				MarkdownDescription: "The description of the fleet.",
@@ -190,12 +252,30 @@ func (r *FleetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "The maximum number of workers that can be started in the fleet.",
 			},
 			"farm_id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The ID of the farm.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the farm. Defaults to the provider `default_farm_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the fleet.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tags": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "The tags to apply to the fleet.",
+			},
+			"tags_all": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "The tags of the fleet, including the provider default tags.",
 			},
 		},
 	}
@@ -207,12 +287,12 @@ func (r *FleetResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*deadline.Client)
+	client, ok := req.ProviderData.(*conns.AWSClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *conns.AWSClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -221,97 +301,331 @@ func (r *FleetResource) Configure(ctx context.Context, req resource.ConfigureReq
 	r.client = client
 }
 
-func createFleetConfiguration(d diag.Diagnostics, data FleetResourceModel) dltypes.FleetConfiguration {
-	var configurationType dltypes.FleetConfiguration
-	if data.Configuration != nil {
-		if data.Configuration.Mode.ValueString() == "customer_managed" {
-			configurationType = &dltypes.FleetConfigurationMemberCustomerManaged{
-				Value: dltypes.CustomerManagedFleetConfiguration{
-					Mode: dltypes.AutoScalingModeEventBasedAutoScaling,
-				},
-			}
-		} else {
-			archType := dltypes.CpuArchitectureTypeX8664
-			archTypeSelector := dltypes.CpuArchitectureType(data.Configuration.Ec2InstanceCapabilities.CpuArchitecture.ValueString())
-			if archTypeSelector == "arm64" {
-				archType = dltypes.CpuArchitectureTypeArm64
-			}
-			osFamily := dltypes.ServiceManagedFleetOperatingSystemFamilyWindows
-			providedOSFamily := strings.Trim(strings.ToLower(data.Configuration.Ec2InstanceCapabilities.OsFamily.ValueString()), " ")
-			if providedOSFamily == "linux" {
-				osFamily = dltypes.ServiceManagedFleetOperatingSystemFamilyLinux
-			} else if providedOSFamily == "windows" {
-				osFamily = dltypes.ServiceManagedFleetOperatingSystemFamilyWindows
-			}
-			marketType := dltypes.Ec2MarketTypeOnDemand
-			if data.Configuration.Ec2MarketType.ValueString() == "spot" {
-				marketType = dltypes.Ec2MarketTypeSpot
-			} else if data.Configuration.Ec2MarketType.ValueString() == "on-demand" {
-				marketType = dltypes.Ec2MarketTypeOnDemand
-			}
-			var aInstances []string
-			if data.Configuration.Ec2InstanceCapabilities.AllowedInstanceType != nil {
-				allowedInstances := data.Configuration.Ec2InstanceCapabilities.AllowedInstanceType
-				if len(allowedInstances) > 0 {
-					for _, aInstance := range allowedInstances {
-						aInstances = append(aInstances, aInstance.ValueString())
-					}
-				}
-			}
-			var eInstances []string
-			if data.Configuration.Ec2InstanceCapabilities.ExcludeInstanceType != nil {
-				excludedInstances := data.Configuration.Ec2InstanceCapabilities.ExcludeInstanceType
-				if len(excludedInstances) > 0 {
-					for _, aInstance := range excludedInstances {
-						eInstances = append(eInstances, aInstance.ValueString())
-					}
-				}
-			}
-			iC := &dltypes.ServiceManagedEc2InstanceCapabilities{
-				CpuArchitectureType: archType,
-				OsFamily:            osFamily,
-				MemoryMiB: &dltypes.MemoryMiBRange{
-					Min: data.Configuration.Ec2InstanceCapabilities.MemoryMibRange.Min.ValueInt32Pointer(),
-					Max: data.Configuration.Ec2InstanceCapabilities.MemoryMibRange.Max.ValueInt32Pointer(),
-				},
+func createFleetConfiguration(d *diag.Diagnostics, data FleetResourceModel) dltypes.FleetConfiguration {
+	if data.Configuration == nil {
+		d.AddError("Client Error", "Configuration is required")
+		return nil
+	}
+	caps := data.Configuration.Ec2InstanceCapabilities
+	if data.Configuration.Mode.ValueString() == "customer_managed" {
+		configuration := dltypes.CustomerManagedFleetConfiguration{
+			Mode: dltypes.AutoScalingModeEventBasedAutoScaling,
+		}
+		if caps != nil {
+			workerCapabilities := &dltypes.CustomerManagedWorkerCapabilities{
+				CpuArchitectureType: createCpuArchitecture(caps.CpuArchitecture),
+				OsFamily:            dltypes.CustomerManagedFleetOperatingSystemFamily(createOsFamily(caps.OsFamily)),
+				MemoryMiB:           createMemoryMiB(caps.MemoryMibRange),
 				VCpuCount: &dltypes.VCpuCountRange{
-					Min: data.Configuration.Ec2InstanceCapabilities.MinCpuCount.ValueInt32Pointer(),
-					Max: data.Configuration.Ec2InstanceCapabilities.MaxCpuCount.ValueInt32Pointer(),
+					Min: caps.MinCpuCount.ValueInt32Pointer(),
+					Max: caps.MaxCpuCount.ValueInt32Pointer(),
 				},
 			}
-			if len(aInstances) > 0 {
-				iC.AllowedInstanceTypes = aInstances
+			if caps.AcceleratorCapabilities != nil && !caps.AcceleratorCapabilities.Count.IsNull() {
+				workerCapabilities.AcceleratorCount = &dltypes.AcceleratorCountRange{
+					Min: caps.AcceleratorCapabilities.Count.ValueInt32Pointer(),
+				}
 			}
-			if len(eInstances) > 0 {
-				iC.ExcludedInstanceTypes = eInstances
+			configuration.WorkerCapabilities = workerCapabilities
+		}
+		return &dltypes.FleetConfigurationMemberCustomerManaged{
+			Value: configuration,
+		}
+	}
+	if caps == nil {
+		d.AddError("Client Error", "configuration.ec2_instance_capabilities is required when the mode is 'aws_managed'")
+		return nil
+	}
+	marketType := dltypes.Ec2MarketTypeOnDemand
+	if data.Configuration.Ec2MarketType.ValueString() == "spot" {
+		marketType = dltypes.Ec2MarketTypeSpot
+	}
+	var aInstances []string
+	for _, aInstance := range caps.AllowedInstanceType {
+		aInstances = append(aInstances, aInstance.ValueString())
+	}
+	var eInstances []string
+	for _, eInstance := range caps.ExcludeInstanceType {
+		eInstances = append(eInstances, eInstance.ValueString())
+	}
+	iC := &dltypes.ServiceManagedEc2InstanceCapabilities{
+		CpuArchitectureType: createCpuArchitecture(caps.CpuArchitecture),
+		OsFamily:            dltypes.ServiceManagedFleetOperatingSystemFamily(createOsFamily(caps.OsFamily)),
+		MemoryMiB:           createMemoryMiB(caps.MemoryMibRange),
+		VCpuCount: &dltypes.VCpuCountRange{
+			Min: caps.MinCpuCount.ValueInt32Pointer(),
+			Max: caps.MaxCpuCount.ValueInt32Pointer(),
+		},
+	}
+	if len(aInstances) > 0 {
+		iC.AllowedInstanceTypes = aInstances
+	}
+	if len(eInstances) > 0 {
+		iC.ExcludedInstanceTypes = eInstances
+	}
+	if caps.RootEBSVolume != nil {
+		iC.RootEbsVolume = &dltypes.Ec2EbsVolume{
+			Iops:          caps.RootEBSVolume.IOPs.ValueInt32Pointer(),
+			SizeGiB:       caps.RootEBSVolume.Size.ValueInt32Pointer(),
+			ThroughputMiB: caps.RootEBSVolume.Throughput.ValueInt32Pointer(),
+		}
+	}
+	if caps.AcceleratorCapabilities != nil {
+		acceleratorCapabilities := &dltypes.AcceleratorCapabilities{}
+		for _, selection := range caps.AcceleratorCapabilities.Selections {
+			acceleratorCapabilities.Selections = append(acceleratorCapabilities.Selections, dltypes.AcceleratorSelection{
+				Name:    dltypes.AcceleratorName(selection.Name.ValueString()),
+				Runtime: selection.Runtime.ValueStringPointer(),
+			})
+		}
+		if !caps.AcceleratorCapabilities.Count.IsNull() {
+			acceleratorCapabilities.Count = &dltypes.AcceleratorCountRange{
+				Min: caps.AcceleratorCapabilities.Count.ValueInt32Pointer(),
 			}
-			configurationType = &dltypes.FleetConfigurationMemberServiceManagedEc2{
-				Value: dltypes.ServiceManagedEc2FleetConfiguration{
-					InstanceCapabilities: iC,
-					InstanceMarketOptions: &dltypes.ServiceManagedEc2InstanceMarketOptions{
-						Type: marketType,
-					},
-				},
+		}
+		iC.AcceleratorCapabilities = acceleratorCapabilities
+	}
+	return &dltypes.FleetConfigurationMemberServiceManagedEc2{
+		Value: dltypes.ServiceManagedEc2FleetConfiguration{
+			InstanceCapabilities: iC,
+			InstanceMarketOptions: &dltypes.ServiceManagedEc2InstanceMarketOptions{
+				Type: marketType,
+			},
+		},
+	}
+}
+
+func createCpuArchitecture(value types.String) dltypes.CpuArchitectureType {
+	if value.ValueString() == "arm64" {
+		return dltypes.CpuArchitectureTypeArm64
+	}
+	return dltypes.CpuArchitectureTypeX8664
+}
+
+// createOsFamily returns the API value of an os_family attribute. Fleets
+// default to Windows.
+func createOsFamily(value types.String) string {
+	switch strings.TrimSpace(strings.ToLower(value.ValueString())) {
+	case "linux":
+		return "LINUX"
+	case "macos":
+		return "MACOS"
+	}
+	return "WINDOWS"
+}
+
+// osFamilyValidator validates os_family against the operating systems of the
+// fleet mode: service-managed fleets do not run macOS.
+type osFamilyValidator struct{}
+
+func (v osFamilyValidator) Description(ctx context.Context) string {
+	return "value must be an operating system family supported by the fleet mode"
+}
+
+func (v osFamilyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v osFamilyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	var mode types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("configuration").AtName("mode"), &mode)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// An unknown mode is checked against every operating system family, and
+	// again once it is known.
+	enum := verify.EnumCaseInsensitive[dltypes.ServiceManagedFleetOperatingSystemFamily]()
+	if mode.IsUnknown() || mode.ValueString() == "customer_managed" {
+		enum = verify.EnumCaseInsensitive[dltypes.CustomerManagedFleetOperatingSystemFamily]()
+	}
+	enum.ValidateString(ctx, req, resp)
+}
+
+func createMemoryMiB(data *FleetResourceEc2InstanceCapabilitiesMemoryRangeeeModel) *dltypes.MemoryMiBRange {
+	if data == nil {
+		return nil
+	}
+	return &dltypes.MemoryMiBRange{
+		Min: data.Min.ValueInt32Pointer(),
+		Max: data.Max.ValueInt32Pointer(),
+	}
+}
+
+// flattenFleetConfiguration returns the configuration block of a fleet
+// returned by GetFleet. prior is the configuration in the state; it keeps
+// the optional attributes that were left to their API defaults null, and the
+// nested blocks that the API fills with defaults absent.
+func flattenFleetConfiguration(prior *FleetResourceConfigurationModel, configuration dltypes.FleetConfiguration) *FleetResourceConfigurationModel {
+	if prior == nil {
+		prior = &FleetResourceConfigurationModel{}
+	}
+	switch v := configuration.(type) {
+	case *dltypes.FleetConfigurationMemberServiceManagedEc2:
+		data := &FleetResourceConfigurationModel{
+			Mode:          flattenString(prior.Mode, "aws_managed", "aws_managed"),
+			Ec2MarketType: types.StringNull(),
+		}
+		if v.Value.InstanceMarketOptions != nil {
+			data.Ec2MarketType = flattenString(prior.Ec2MarketType, string(v.Value.InstanceMarketOptions.Type), string(dltypes.Ec2MarketTypeOnDemand))
+		}
+		if v.Value.InstanceCapabilities != nil {
+			data.Ec2InstanceCapabilities = flattenServiceManagedCapabilities(prior.Ec2InstanceCapabilities, v.Value.InstanceCapabilities)
+		}
+		return data
+	case *dltypes.FleetConfigurationMemberCustomerManaged:
+		data := &FleetResourceConfigurationModel{
+			Mode:          types.StringValue("customer_managed"),
+			Ec2MarketType: prior.Ec2MarketType,
+		}
+		if v.Value.WorkerCapabilities != nil {
+			data.Ec2InstanceCapabilities = flattenCustomerManagedCapabilities(prior.Ec2InstanceCapabilities, v.Value.WorkerCapabilities)
+		}
+		return data
+	}
+	return prior
+}
+
+func flattenServiceManagedCapabilities(prior *FleetResourceEc2InstanceCapabilitiesModel, caps *dltypes.ServiceManagedEc2InstanceCapabilities) *FleetResourceEc2InstanceCapabilitiesModel {
+	if prior == nil {
+		prior = &FleetResourceEc2InstanceCapabilitiesModel{}
+	}
+	data := &FleetResourceEc2InstanceCapabilitiesModel{
+		CpuArchitecture:     flattenString(prior.CpuArchitecture, string(caps.CpuArchitectureType), string(dltypes.CpuArchitectureTypeX8664)),
+		OsFamily:            flattenString(prior.OsFamily, strings.ToLower(string(caps.OsFamily)), "windows"),
+		MemoryMibRange:      flattenMemoryMiB(caps.MemoryMiB),
+		AllowedInstanceType: flattenStrings(prior.AllowedInstanceType, caps.AllowedInstanceTypes),
+		ExcludeInstanceType: flattenStrings(prior.ExcludeInstanceType, caps.ExcludedInstanceTypes),
+	}
+	data.MinCpuCount, data.MaxCpuCount = flattenVCpuCount(caps.VCpuCount)
+	// The API fills in a default root volume, which is only tracked when it
+	// is configured.
+	if caps.RootEbsVolume != nil && prior.RootEBSVolume != nil {
+		data.RootEBSVolume = &FleetResourceEc2InstanceCapabilitiesRootEBSVolumeModel{
+			IOPs:       types.Int32PointerValue(caps.RootEbsVolume.Iops),
+			Size:       types.Int32PointerValue(caps.RootEbsVolume.SizeGiB),
+			Throughput: types.Int32PointerValue(caps.RootEbsVolume.ThroughputMiB),
+		}
+	}
+	if caps.AcceleratorCapabilities != nil {
+		var priorSelections []FleetResourceAcceleratorSelectionModel
+		if prior.AcceleratorCapabilities != nil {
+			priorSelections = prior.AcceleratorCapabilities.Selections
+		}
+		accelerators := &FleetResourceEc2InstanceCapabilitiesAcceleratorCapabilitiesModel{
+			Count: types.Int32Null(),
+		}
+		for i, selection := range caps.AcceleratorCapabilities.Selections {
+			priorRuntime := types.StringNull()
+			if i < len(priorSelections) {
+				priorRuntime = priorSelections[i].Runtime
 			}
-			if len(aInstances) > 0 {
-				iC.AllowedInstanceTypes = aInstances
+			runtime := types.StringPointerValue(selection.Runtime)
+			if priorRuntime.IsNull() {
+				runtime = types.StringNull()
 			}
+			accelerators.Selections = append(accelerators.Selections, FleetResourceAcceleratorSelectionModel{
+				Name:    types.StringValue(string(selection.Name)),
+				Runtime: runtime,
+			})
 		}
-	} else {
-		d.AddError("Client Error", "Configuration is required")
+		if caps.AcceleratorCapabilities.Count != nil {
+			accelerators.Count = types.Int32PointerValue(caps.AcceleratorCapabilities.Count.Min)
+		}
+		data.AcceleratorCapabilities = accelerators
+	}
+	return data
+}
+
+func flattenCustomerManagedCapabilities(prior *FleetResourceEc2InstanceCapabilitiesModel, caps *dltypes.CustomerManagedWorkerCapabilities) *FleetResourceEc2InstanceCapabilitiesModel {
+	if prior == nil {
+		prior = &FleetResourceEc2InstanceCapabilitiesModel{}
+	}
+	data := &FleetResourceEc2InstanceCapabilitiesModel{
+		CpuArchitecture:     flattenString(prior.CpuArchitecture, string(caps.CpuArchitectureType), string(dltypes.CpuArchitectureTypeX8664)),
+		OsFamily:            flattenString(prior.OsFamily, strings.ToLower(string(caps.OsFamily)), "windows"),
+		MemoryMibRange:      flattenMemoryMiB(caps.MemoryMiB),
+		AllowedInstanceType: prior.AllowedInstanceType,
+		ExcludeInstanceType: prior.ExcludeInstanceType,
+		RootEBSVolume:       prior.RootEBSVolume,
+	}
+	data.MinCpuCount, data.MaxCpuCount = flattenVCpuCount(caps.VCpuCount)
+	if caps.AcceleratorCount != nil {
+		data.AcceleratorCapabilities = &FleetResourceEc2InstanceCapabilitiesAcceleratorCapabilitiesModel{
+			Count: types.Int32PointerValue(caps.AcceleratorCount.Min),
+		}
+		if prior.AcceleratorCapabilities != nil {
+			data.AcceleratorCapabilities.Selections = prior.AcceleratorCapabilities.Selections
+		}
+	}
+	return data
+}
+
+func flattenMemoryMiB(memory *dltypes.MemoryMiBRange) *FleetResourceEc2InstanceCapabilitiesMemoryRangeeeModel {
+	if memory == nil {
+		return nil
+	}
+	return &FleetResourceEc2InstanceCapabilitiesMemoryRangeeeModel{
+		Min: types.Int32PointerValue(memory.Min),
+		Max: types.Int32PointerValue(memory.Max),
 	}
-	return configurationType
+}
+
+func flattenVCpuCount(count *dltypes.VCpuCountRange) (types.Int32, types.Int32) {
+	if count == nil {
+		return types.Int32Null(), types.Int32Null()
+	}
+	return types.Int32PointerValue(count.Min), types.Int32PointerValue(count.Max)
+}
+
+// flattenString returns value, unless prior is null and value is the API
+// default, or prior is the same value written differently.
+func flattenString(prior types.String, value, defaultValue string) types.String {
+	if prior.IsNull() && value == defaultValue {
+		return prior
+	}
+	if strings.EqualFold(strings.TrimSpace(prior.ValueString()), value) {
+		return prior
+	}
+	return types.StringValue(value)
+}
+
+// flattenStrings returns values as a list, keeping an absent prior list
+// absent when the API returns no values.
+func flattenStrings(prior []types.String, values []string) []types.String {
+	if len(values) == 0 && prior == nil {
+		return nil
+	}
+	list := make([]types.String, 0, len(values))
+	for _, value := range values {
+		list = append(list, types.StringValue(value))
+	}
+	return list
 }
 
 func (r *FleetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "FleetResource.Create")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("create", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data FleetResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	configurationType := createFleetConfiguration(resp.Diagnostics, data)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	configurationType := createFleetConfiguration(&resp.Diagnostics, data)
+	resourceTags, diags := tags.FromValue(ctx, data.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = r.logFields(ctx, data)
+	allTags := r.client.IgnoreTagsConfig.Filter(r.client.DefaultTagsConfig.MergeTags(resourceTags))
 	createRequest := deadline.CreateFleetInput{
 		FarmId:         data.FarmId.ValueStringPointer(),
 		MinWorkerCount: data.MinWorkerCount.ValueInt32(),
@@ -320,8 +634,9 @@ func (r *FleetResource) Create(ctx context.Context, req resource.CreateRequest,
 		Description:    data.Description.ValueStringPointer(),
 		RoleArn:        data.RoleArn.ValueStringPointer(),
 		Configuration:  configurationType,
+		Tags:           allTags,
 	}
-	createOutputRaw, err := r.client.CreateFleet(ctx, &createRequest)
+	createOutputRaw, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).CreateFleet(ctx, &createRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create %s, %s, got error: %s", r.typeName(), data.DisplayName.String(), err))
 		return
@@ -336,12 +651,36 @@ func (r *FleetResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	data.ID = types.StringValue(*createOutput.FleetId)
+	ctx = r.logFields(ctx, data)
+	fleetARN, err := r.client.DeadlineARN(ctx, data.Region.ValueString(), r.resourceARN(data))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s ARN, got error: %s", r.typeName(), err))
+		return
+	}
+	data.Arn = types.StringValue(fleetARN)
+	data.TagsAll, diags = tags.ToValue(ctx, allTags)
+	resp.Diagnostics.Append(diags...)
+	createTimeout, diags := data.Timeouts.Create(ctx, fleetTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err = waitFleetActive(ctx, r.client.DeadlineClientForRegion(data.Region.ValueString()), data, createTimeout)
+	if err != nil {
+		// Keep the fleet in the state so that it is replaced or deleted by the next apply.
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create %s, %s, got error: %s", r.typeName(), data.DisplayName.String(), err))
+		return
+	}
 	tflog.Trace(ctx, fmt.Sprintf("created %s", r.typeName()))
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *FleetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "FleetResource.Read")
+	defer tracing.End(span, &resp.Diagnostics)
+
 	var data FleetResourceModel
 
 	// Read Terraform prior state data into the model
@@ -350,39 +689,126 @@ func (r *FleetResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
+	// State written before the region attribute existed has no region.
+	if data.Region.IsNull() {
+		data.Region = types.StringValue(r.client.Region)
+	}
+	conn := r.client.DeadlineClientForRegion(data.Region.ValueString())
+	fleet, err := conn.GetFleet(ctx, &deadline.GetFleetInput{
+		FarmId:  data.FarmId.ValueStringPointer(),
+		FleetId: data.ID.ValueStringPointer(),
+	})
+	if err != nil {
+		if tfresource.NotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("%s not found, removing it from the state", r.typeName()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s, got error: %s", r.typeName(), err))
+		return
+	}
+	data.FarmId = types.StringPointerValue(fleet.FarmId)
+	data.DisplayName = types.StringPointerValue(fleet.DisplayName)
+	if (fleet.Description != nil && *fleet.Description != "") || !data.Description.IsNull() {
+		data.Description = types.StringPointerValue(fleet.Description)
+	}
+	data.RoleArn = types.StringPointerValue(fleet.RoleArn)
+	data.MinWorkerCount = types.Int32PointerValue(fleet.MinWorkerCount)
+	data.MaxWorkerCount = types.Int32PointerValue(fleet.MaxWorkerCount)
+	data.Configuration = flattenFleetConfiguration(data.Configuration, fleet.Configuration)
+	fleetARN, err := r.client.DeadlineARN(ctx, data.Region.ValueString(), r.resourceARN(data))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s ARN, got error: %s", r.typeName(), err))
+		return
+	}
+	data.Arn = types.StringValue(fleetARN)
+	remoteTags, err := tags.List(ctx, conn, fleetARN)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s tags, got error: %s", r.typeName(), err))
+		return
+	}
+	var diags diag.Diagnostics
+	data.Tags, data.TagsAll, diags = tags.Flatten(ctx, r.client.DefaultTagsConfig, r.client.IgnoreTagsConfig, data.Tags, remoteTags)
+	resp.Diagnostics.Append(diags...)
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *FleetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data FleetResourceModel
+	ctx, span := tracing.Start(ctx, "FleetResource.Update")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("update", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data, state FleetResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
-	configurationType := createFleetConfiguration(resp.Diagnostics, data)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	configurationType := createFleetConfiguration(&resp.Diagnostics, data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = r.logFields(ctx, data)
+	data.Arn = state.Arn
 	request := &deadline.UpdateFleetInput{
-		FarmId:      data.FarmId.ValueStringPointer(),
-		FleetId:     data.ID.ValueStringPointer(),
-		Description: data.Description.ValueStringPointer(),
-		DisplayName: data.DisplayName.ValueStringPointer(),
+		FarmId:         data.FarmId.ValueStringPointer(),
+		FleetId:        data.ID.ValueStringPointer(),
+		Description:    data.Description.ValueStringPointer(),
+		DisplayName:    data.DisplayName.ValueStringPointer(),
+		MinWorkerCount: data.MinWorkerCount.ValueInt32Pointer(),
+		MaxWorkerCount: data.MaxWorkerCount.ValueInt32Pointer(),
+		RoleArn:        data.RoleArn.ValueStringPointer(),
+		Configuration:  configurationType,
+	}
+	conn := r.client.DeadlineClientForRegion(data.Region.ValueString())
+	_, err := conn.UpdateFleet(ctx, request)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update %s, got error: %s", r.typeName(), err))
+		return
+	}
+	updateTimeout, diags := data.Timeouts.Update(ctx, fleetTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	request.Configuration = configurationType
-	_, err := r.client.UpdateFleet(ctx, request)
+	err = waitFleetActive(ctx, conn, data, updateTimeout)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update %s, got error: %s", r.typeName(), err))
 		return
 	}
-	data.Description = types.StringValue(*request.Description)
-	data.DisplayName = types.StringValue(*request.DisplayName)
+	if !data.TagsAll.Equal(state.TagsAll) {
+		fleetARN, err := r.client.DeadlineARN(ctx, data.Region.ValueString(), r.resourceARN(data))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s ARN, got error: %s", r.typeName(), err))
+			return
+		}
+		resp.Diagnostics.Append(tags.UpdateValues(ctx, conn, r.client.IgnoreTagsConfig, fleetARN, state.TagsAll, data.TagsAll)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *FleetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "FleetResource.Delete")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("delete", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data FleetResourceModel
 
 	// Read Terraform prior state data into the model
@@ -391,21 +817,119 @@ func (r *FleetResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	deleteResourceRequest := &deadline.DeleteFleetInput{
 		FarmId:  data.FarmId.ValueStringPointer(),
 		FleetId: data.ID.ValueStringPointer(),
 	}
-	_, err := r.client.DeleteFleet(ctx, deleteResourceRequest)
+	conn := r.client.DeadlineClientForRegion(data.Region.ValueString())
+	_, err := conn.DeleteFleet(ctx, deleteResourceRequest)
+	if tfresource.NotFound(err) {
+		// A resource that is already gone is deleted.
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", r.typeName(), err))
+		return
+	}
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, fleetTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Fleets have no deletion status; they keep their last status until they
+	// are gone.
+	pending := []string{string(dltypes.FleetStatusActive), string(dltypes.FleetStatusUpdateInProgress)}
+	err = tfresource.WaitForStatus(ctx, deleteTimeout, pending, nil, statusFleet(conn, data))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", r.typeName(), err))
 		return
 	}
 }
 
+func (r *FleetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		return
+	}
+	r.client.ModifyPlanRegion(ctx, req, resp)
+	r.client.ModifyPlanFarmID(ctx, req, resp)
+	tags.ModifyPlan(ctx, r.client.DefaultTagsConfig, r.client.IgnoreTagsConfig, req, resp)
+}
+
 func (r *FleetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	tfresource.ImportCompositeIDOrName(ctx, req, resp, r.idsByName, "farm_id", "id")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Imported resources, including the ones found by display name, are
+	// looked up in the provider region.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("region"), r.client.Region)...)
 }
 
 func (r *FleetResource) typeName() string {
 	return "deadline_fleet"
 }
+
+// resourceARN returns the resource part of the fleet ARN.
+func (r *FleetResource) resourceARN(data FleetResourceModel) string {
+	return fmt.Sprintf("farm/%s/fleet/%s", data.FarmId.ValueString(), data.ID.ValueString())
+}
+
+// logFields sets the identifiers of the fleet as fields of the log lines.
+func (r *FleetResource) logFields(ctx context.Context, data FleetResourceModel) context.Context {
+	return conns.LogFields(ctx, map[string]types.String{
+		"farm_id":  data.FarmId,
+		"fleet_id": data.ID,
+	})
+}
+
+// fleetTimeout is the default time to wait for a fleet to settle.
+const fleetTimeout = 30 * time.Minute
+
+// statusFleet reports the status of the fleet. Deadline does not return a
+// status message for fleets.
+func statusFleet(conn *deadline.Client, data FleetResourceModel) tfresource.StatusFunc {
+	return func(ctx context.Context) (string, string, error) {
+		fleet, err := conn.GetFleet(ctx, &deadline.GetFleetInput{
+			FarmId:  data.FarmId.ValueStringPointer(),
+			FleetId: data.ID.ValueStringPointer(),
+		})
+		if err != nil {
+			return "", "", err
+		}
+		return string(fleet.Status), "", nil
+	}
+}
+
+// waitFleetActive waits for a created or updated fleet to become active.
+func waitFleetActive(ctx context.Context, conn *deadline.Client, data FleetResourceModel, timeout time.Duration) error {
+	pending := []string{
+		string(dltypes.FleetStatusCreateInProgress),
+		string(dltypes.FleetStatusUpdateInProgress),
+	}
+	target := []string{string(dltypes.FleetStatusActive)}
+	return tfresource.WaitForStatus(ctx, timeout, pending, target, statusFleet(conn, data))
+}
+
+// idsByName returns the IDs of the fleets of the farm parents[0] named name,
+// in the provider region.
+func (r *FleetResource) idsByName(ctx context.Context, parents []string, name string) ([]string, error) {
+	var ids []string
+	paginator := deadline.NewListFleetsPaginator(r.client.DeadlineClient, &deadline.ListFleetsInput{
+		FarmId:      aws.String(parents[0]),
+		DisplayName: aws.String(name),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, fleet := range page.Fleets {
+			if aws.ToString(fleet.DisplayName) == name {
+				ids = append(ids, aws.ToString(fleet.FleetId))
+			}
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fleet
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFleetConfigurationRoundTrip(t *testing.T) {
+	configuration := &FleetResourceConfigurationModel{
+		Mode:          types.StringNull(),
+		Ec2MarketType: types.StringValue("spot"),
+		Ec2InstanceCapabilities: &FleetResourceEc2InstanceCapabilitiesModel{
+			CpuArchitecture: types.StringNull(),
+			MinCpuCount:     types.Int32Value(2),
+			MaxCpuCount:     types.Int32Null(),
+			MemoryMibRange: &FleetResourceEc2InstanceCapabilitiesMemoryRangeeeModel{
+				Min: types.Int32Value(4096),
+				Max: types.Int32Null(),
+			},
+			OsFamily:            types.StringValue("Linux"),
+			AllowedInstanceType: []types.String{types.StringValue("c5.large")},
+			RootEBSVolume: &FleetResourceEc2InstanceCapabilitiesRootEBSVolumeModel{
+				IOPs:       types.Int32Null(),
+				Size:       types.Int32Value(100),
+				Throughput: types.Int32Null(),
+			},
+		},
+	}
+	var diags diag.Diagnostics
+	expanded := createFleetConfiguration(&diags, FleetResourceModel{Configuration: configuration})
+	if diags.HasError() {
+		t.Fatalf("createFleetConfiguration() returned errors: %v", diags)
+	}
+	got := flattenFleetConfiguration(configuration, expanded)
+	if !reflect.DeepEqual(got, configuration) {
+		t.Errorf("flattenFleetConfiguration() = %+v, want %+v", got.Ec2InstanceCapabilities, configuration.Ec2InstanceCapabilities)
+	}
+}
+
+func TestFlattenString(t *testing.T) {
+	testCases := map[string]struct {
+		prior types.String
+		value string
+		want  types.String
+	}{
+		"default kept null": {prior: types.StringNull(), value: "x86_64", want: types.StringNull()},
+		"drift from null":   {prior: types.StringNull(), value: "arm64", want: types.StringValue("arm64")},
+		"same value":        {prior: types.StringValue("Linux"), value: "linux", want: types.StringValue("Linux")},
+		"drift":             {prior: types.StringValue("linux"), value: "windows", want: types.StringValue("windows")},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := flattenString(tc.prior, tc.value, "x86_64"); !got.Equal(tc.want) {
+				t.Errorf("flattenString() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOSFamilyValidator(t *testing.T) {
+	ctx := context.Background()
+	configSchema := schema.Schema{
+		Blocks: map[string]schema.Block{
+			"configuration": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"mode": schema.StringAttribute{Optional: true},
+				},
+			},
+		},
+	}
+	configType := configSchema.Type().TerraformType(ctx)
+	config := func(mode tftypes.Value) tfsdk.Config {
+		return tfsdk.Config{
+			Schema: configSchema,
+			Raw: tftypes.NewValue(configType, map[string]tftypes.Value{
+				"configuration": tftypes.NewValue(configType.(tftypes.Object).AttributeTypes["configuration"], map[string]tftypes.Value{
+					"mode": mode,
+				}),
+			}),
+		}
+	}
+	testCases := map[string]struct {
+		mode      tftypes.Value
+		osFamily  string
+		wantError bool
+	}{
+		"service managed linux":    {mode: tftypes.NewValue(tftypes.String, "aws_managed"), osFamily: "linux"},
+		"service managed macos":    {mode: tftypes.NewValue(tftypes.String, "aws_managed"), osFamily: "macos", wantError: true},
+		"default mode macos":       {mode: tftypes.NewValue(tftypes.String, nil), osFamily: "macos", wantError: true},
+		"customer managed macos":   {mode: tftypes.NewValue(tftypes.String, "customer_managed"), osFamily: "macos"},
+		"customer managed unknown": {mode: tftypes.NewValue(tftypes.String, "customer_managed"), osFamily: "beos", wantError: true},
+		"unknown mode macos":       {mode: tftypes.NewValue(tftypes.String, tftypes.UnknownValue), osFamily: "macos"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("configuration").AtName("ec2_instance_capabilities").AtName("os_family"),
+				ConfigValue: types.StringValue(tc.osFamily),
+				Config:      config(tc.mode),
+			}
+			resp := &validator.StringResponse{}
+			osFamilyValidator{}.ValidateString(ctx, req, resp)
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Errorf("ValidateString(%q) error = %v, want %v: %v", tc.osFamily, got, tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
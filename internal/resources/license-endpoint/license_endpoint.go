@@ -7,16 +7,28 @@ import (
 	"context"
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/service/deadline"
+	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/conns"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tags"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tfresource"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tracing"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"time"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &LicenseEndpointResource{}
 var _ resource.ResourceWithImportState = &LicenseEndpointResource{}
+var _ resource.ResourceWithModifyPlan = &LicenseEndpointResource{}
 
 func New() resource.Resource {
 	return &LicenseEndpointResource{}
@@ -24,15 +36,20 @@ func New() resource.Resource {
 
 // LicenseEndpointResource defines the resource implementation.
 type LicenseEndpointResource struct {
-	client *deadline.Client
+	client *conns.AWSClient
 }
 
 // LicenseEndpointResourceModel describes the resource data model.
 type LicenseEndpointResourceModel struct {
+	Arn              types.String   `tfsdk:"arn"`
+	Region           types.String   `tfsdk:"region"`
 	SecurityGroupIds []types.String `tfsdk:"security_group_ids"`
 	SubnetIds        []types.String `tfsdk:"subnet_ids"`
 	VpcId            types.String   `tfsdk:"vpc_id"`
 	ID               types.String   `tfsdk:"id"`
+	Tags             types.Map      `tfsdk:"tags"`
+	TagsAll          types.Map      `tfsdk:"tags_all"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *LicenseEndpointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -43,24 +60,64 @@ func (r *LicenseEndpointResource) Schema(ctx context.Context, req resource.Schem
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "LicenseEndpoint resource",
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
 		Attributes: map[string]schema.Attribute{
+			"arn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ARN of the license endpoint.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The AWS region of the resource. Defaults to the provider region. Changing it replaces the resource.",
+				Optional:            true,
+				Computed:            true,
+			},
 			"security_group_ids": schema.ListAttribute{
 				ElementType:         types.StringType,
 				Required:            true,
 				MarkdownDescription: "The security groups that will be associated with the license endpoint",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
 			},
 			"subnet_ids": schema.ListAttribute{
 				ElementType:         types.StringType,
 				MarkdownDescription: "The subnet ids that will be associated to the license endpoint",
 				Required:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
 			},
 			"vpc_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The VPC ID that the license endpoint is associated with",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the licenseEndpoint.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tags": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "The tags to apply to the license endpoint.",
+			},
+			"tags_all": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "The tags of the license endpoint, including the provider default tags.",
 			},
 		},
 	}
@@ -72,12 +129,12 @@ func (r *LicenseEndpointResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 
-	client, ok := req.ProviderData.(*deadline.Client)
+	client, ok := req.ProviderData.(*conns.AWSClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *conns.AWSClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -87,6 +144,14 @@ func (r *LicenseEndpointResource) Configure(ctx context.Context, req resource.Co
 }
 
 func (r *LicenseEndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "LicenseEndpointResource.Create")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("create", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data LicenseEndpointResourceModel
 
 	// Read Terraform plan data into the model
@@ -95,31 +160,66 @@ func (r *LicenseEndpointResource) Create(ctx context.Context, req resource.Creat
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	subnets := []string{}
 	for _, subnet := range data.SubnetIds {
-		subnets = append(subnets, subnet.String())
+		subnets = append(subnets, subnet.ValueString())
 	}
 	sgIds := []string{}
 	for _, sgId := range data.SecurityGroupIds {
-		sgIds = append(sgIds, sgId.String())
+		sgIds = append(sgIds, sgId.ValueString())
+	}
+	resourceTags, diags := tags.FromValue(ctx, data.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	allTags := r.client.IgnoreTagsConfig.Filter(r.client.DefaultTagsConfig.MergeTags(resourceTags))
 	licenseEndpointRequest := deadline.CreateLicenseEndpointInput{
 		VpcId:            data.VpcId.ValueStringPointer(),
 		SubnetIds:        subnets,
 		SecurityGroupIds: sgIds,
+		Tags:             allTags,
 	}
-	licenseEndpointOutput, err := r.client.CreateLicenseEndpoint(ctx, &licenseEndpointRequest)
+	conn := r.client.DeadlineClientForRegion(data.Region.ValueString())
+	licenseEndpointOutput, err := conn.CreateLicenseEndpoint(ctx, &licenseEndpointRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create %s, got error: %s", r.typeName(), err))
 		return
 	}
 	data.ID = types.StringValue(*licenseEndpointOutput.LicenseEndpointId)
+	ctx = r.logFields(ctx, data)
+	licenseEndpointARN, err := r.client.DeadlineARN(ctx, data.Region.ValueString(), "license-endpoint/"+data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s ARN, got error: %s", r.typeName(), err))
+		return
+	}
+	data.Arn = types.StringValue(licenseEndpointARN)
+	data.TagsAll, diags = tags.ToValue(ctx, allTags)
+	resp.Diagnostics.Append(diags...)
+	createTimeout, diags := data.Timeouts.Create(ctx, licenseEndpointTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pending := []string{string(dltypes.LicenseEndpointStatusCreateInProgress)}
+	target := []string{string(dltypes.LicenseEndpointStatusReady)}
+	err = tfresource.WaitForStatus(ctx, createTimeout, pending, target, statusLicenseEndpoint(conn, data.ID.ValueString()))
+	if err != nil {
+		// Keep the license endpoint in the state so that it is replaced or deleted by the next apply.
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create %s, got error: %s", r.typeName(), err))
+		return
+	}
 	tflog.Trace(ctx, fmt.Sprintf("created %s", r.typeName()))
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *LicenseEndpointResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "LicenseEndpointResource.Read")
+	defer tracing.End(span, &resp.Diagnostics)
+
 	var data LicenseEndpointResourceModel
 
 	// Read Terraform prior state data into the model
@@ -128,32 +228,90 @@ func (r *LicenseEndpointResource) Read(ctx context.Context, req resource.ReadReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	licenseEndpointResponse, err := r.client.GetLicenseEndpoint(ctx, &deadline.GetLicenseEndpointInput{
+	ctx = r.logFields(ctx, data)
+	// State written before the region attribute existed has no region.
+	if data.Region.IsNull() {
+		data.Region = types.StringValue(r.client.Region)
+	}
+	conn := r.client.DeadlineClientForRegion(data.Region.ValueString())
+	licenseEndpointResponse, err := conn.GetLicenseEndpoint(ctx, &deadline.GetLicenseEndpointInput{
 		LicenseEndpointId: data.ID.ValueStringPointer(),
 	})
 	if err != nil {
+		if tfresource.NotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("%s not found, removing it from the state", r.typeName()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s, got error: %s", r.typeName(), err))
 		return
 	}
 	data.ID = types.StringValue(*licenseEndpointResponse.LicenseEndpointId)
+	data.VpcId = types.StringPointerValue(licenseEndpointResponse.VpcId)
+	data.SubnetIds = flattenStrings(licenseEndpointResponse.SubnetIds)
+	data.SecurityGroupIds = flattenStrings(licenseEndpointResponse.SecurityGroupIds)
+	licenseEndpointARN, err := r.client.DeadlineARN(ctx, data.Region.ValueString(), "license-endpoint/"+data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s ARN, got error: %s", r.typeName(), err))
+		return
+	}
+	data.Arn = types.StringValue(licenseEndpointARN)
+	remoteTags, err := tags.List(ctx, conn, licenseEndpointARN)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s tags, got error: %s", r.typeName(), err))
+		return
+	}
+	var diags diag.Diagnostics
+	data.Tags, data.TagsAll, diags = tags.Flatten(ctx, r.client.DefaultTagsConfig, r.client.IgnoreTagsConfig, data.Tags, remoteTags)
+	resp.Diagnostics.Append(diags...)
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *LicenseEndpointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data LicenseEndpointResourceModel
+	ctx, span := tracing.Start(ctx, "LicenseEndpointResource.Update")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("update", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data, state LicenseEndpointResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
+	data.Arn = state.Arn
+	if !data.TagsAll.Equal(state.TagsAll) {
+		licenseEndpointARN, err := r.client.DeadlineARN(ctx, data.Region.ValueString(), "license-endpoint/"+data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s ARN, got error: %s", r.typeName(), err))
+			return
+		}
+		resp.Diagnostics.Append(tags.UpdateValues(ctx, r.client.DeadlineClientForRegion(data.Region.ValueString()), r.client.IgnoreTagsConfig, licenseEndpointARN, state.TagsAll, data.TagsAll)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *LicenseEndpointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "LicenseEndpointResource.Delete")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("delete", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data LicenseEndpointResourceModel
 
 	// Read Terraform prior state data into the model
@@ -162,16 +320,42 @@ func (r *LicenseEndpointResource) Delete(ctx context.Context, req resource.Delet
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	deleteResourceRequest := &deadline.DeleteLicenseEndpointInput{
 		LicenseEndpointId: data.ID.ValueStringPointer(),
 	}
-	_, err := r.client.DeleteLicenseEndpoint(ctx, deleteResourceRequest)
+	conn := r.client.DeadlineClientForRegion(data.Region.ValueString())
+	_, err := conn.DeleteLicenseEndpoint(ctx, deleteResourceRequest)
+	if tfresource.NotFound(err) {
+		// A resource that is already gone is deleted.
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", r.typeName(), err))
+		return
+	}
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, licenseEndpointTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pending := []string{string(dltypes.LicenseEndpointStatusReady), string(dltypes.LicenseEndpointStatusDeleteInProgress)}
+	err = tfresource.WaitForStatus(ctx, deleteTimeout, pending, nil, statusLicenseEndpoint(conn, data.ID.ValueString()))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", r.typeName(), err))
 		return
 	}
 }
 
+func (r *LicenseEndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		return
+	}
+	r.client.ModifyPlanRegion(ctx, req, resp)
+	tags.ModifyPlan(ctx, r.client.DefaultTagsConfig, r.client.IgnoreTagsConfig, req, resp)
+}
+
 func (r *LicenseEndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -179,3 +363,40 @@ func (r *LicenseEndpointResource) ImportState(ctx context.Context, req resource.
 func (r *LicenseEndpointResource) typeName() string {
 	return "deadline_license_endpoint"
 }
+
+// logFields sets the identifiers of the license endpoint as fields of the log lines.
+func (r *LicenseEndpointResource) logFields(ctx context.Context, data LicenseEndpointResourceModel) context.Context {
+	return conns.LogFields(ctx, map[string]types.String{
+		"license_endpoint_id": data.ID,
+	})
+}
+
+// flattenStrings returns values as a list attribute value.
+func flattenStrings(values []string) []types.String {
+	list := make([]types.String, 0, len(values))
+	for _, value := range values {
+		list = append(list, types.StringValue(value))
+	}
+	return list
+}
+
+// licenseEndpointTimeout is the default time to wait for a license endpoint to settle.
+const licenseEndpointTimeout = 30 * time.Minute
+
+// statusLicenseEndpoint reports the status of the license endpoint and the
+// reason given for it.
+func statusLicenseEndpoint(conn *deadline.Client, id string) tfresource.StatusFunc {
+	return func(ctx context.Context) (string, string, error) {
+		licenseEndpoint, err := conn.GetLicenseEndpoint(ctx, &deadline.GetLicenseEndpointInput{
+			LicenseEndpointId: &id,
+		})
+		if err != nil {
+			return "", "", err
+		}
+		var message string
+		if licenseEndpoint.StatusMessage != nil {
+			message = *licenseEndpoint.StatusMessage
+		}
+		return string(licenseEndpoint.Status), message, nil
+	}
+}
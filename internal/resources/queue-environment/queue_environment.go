@@ -8,16 +8,24 @@ import (
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/service/deadline"
 	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/conns"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tfresource"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tracing"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/verify"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strings"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &QueueEnvironmentResource{}
 var _ resource.ResourceWithImportState = &QueueEnvironmentResource{}
+var _ resource.ResourceWithModifyPlan = &QueueEnvironmentResource{}
 
 func New() resource.Resource {
 	return &QueueEnvironmentResource{}
@@ -25,11 +33,12 @@ func New() resource.Resource {
 
 // QueueEnvironmentResource defines the resource implementation.
 type QueueEnvironmentResource struct {
-	client *deadline.Client
+	client *conns.AWSClient
 }
 
 // QueueEnvironmentResourceModel describes the resource data model.
 type QueueEnvironmentResourceModel struct {
+	Region       types.String `tfsdk:"region"`
 	QueueId      types.String `tfsdk:"queue_id"`
 	FarmId       types.String `tfsdk:"farm_id"`
 	Priority     types.Int32  `tfsdk:"priority"`
@@ -48,9 +57,19 @@ func (r *QueueEnvironmentResource) Schema(ctx context.Context, req resource.Sche
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "QueueEnvironment resource",
 		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The AWS region of the resource. Defaults to the provider region. Changing it replaces the resource.",
+				Optional:            true,
+				Computed:            true,
+			},
 			"farm_id": schema.StringAttribute{
-				MarkdownDescription: "The display name of the queueEnvironment.",
-				Required:            true,
+				MarkdownDescription: "The ID of the farm. Defaults to the provider `default_farm_id`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"priority": schema.Int32Attribute{
 				MarkdownDescription: "sets the priority of the environments in the queue from 0 to 10,000, where 0 is the highest priority. If two environments share the same priority value, the environment created first takes higher priority.",
@@ -59,6 +78,9 @@ func (r *QueueEnvironmentResource) Schema(ctx context.Context, req resource.Sche
 			"queue_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the queue.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"template": schema.StringAttribute{
 				Required:    true,
@@ -67,10 +89,16 @@ func (r *QueueEnvironmentResource) Schema(ctx context.Context, req resource.Sche
 			"template_type": schema.StringAttribute{
 				Required:    true,
 				Description: "The environment template to use in the queue. Can be either json or yaml",
+				Validators: []validator.String{
+					verify.EnumCaseInsensitive[dltypes.EnvironmentTemplateType](),
+				},
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the queueEnvironment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Computed:    true,
@@ -86,12 +114,12 @@ func (r *QueueEnvironmentResource) Configure(ctx context.Context, req resource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*deadline.Client)
+	client, ok := req.ProviderData.(*conns.AWSClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *conns.AWSClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -101,6 +129,14 @@ func (r *QueueEnvironmentResource) Configure(ctx context.Context, req resource.C
 }
 
 func (r *QueueEnvironmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "QueueEnvironmentResource.Create")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("create", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data QueueEnvironmentResourceModel
 
 	// Read Terraform plan data into the model
@@ -109,19 +145,24 @@ func (r *QueueEnvironmentResource) Create(ctx context.Context, req resource.Crea
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	queueEnvironmentRequest := deadline.CreateQueueEnvironmentInput{}
-	queueEnvironmentOutput, err := r.client.CreateQueueEnvironment(ctx, &queueEnvironmentRequest)
+	queueEnvironmentOutput, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).CreateQueueEnvironment(ctx, &queueEnvironmentRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create %s, got error: %s", r.typeName(), err))
 		return
 	}
 	data.ID = types.StringValue(*queueEnvironmentOutput.QueueEnvironmentId)
+	ctx = r.logFields(ctx, data)
 	tflog.Trace(ctx, fmt.Sprintf("created %s", r.typeName()))
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *QueueEnvironmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "QueueEnvironmentResource.Read")
+	defer tracing.End(span, &resp.Diagnostics)
+
 	var data QueueEnvironmentResourceModel
 
 	// Read Terraform prior state data into the model
@@ -130,10 +171,18 @@ func (r *QueueEnvironmentResource) Read(ctx context.Context, req resource.ReadRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	queueEnvironmentResponse, err := r.client.GetQueueEnvironment(ctx, &deadline.GetQueueEnvironmentInput{
-		QueueEnvironmentId: data.ID.ValueStringPointer(),
-	})
+	ctx = r.logFields(ctx, data)
+	// State written before the region attribute existed has no region.
+	if data.Region.IsNull() {
+		data.Region = types.StringValue(r.client.Region)
+	}
+	queueEnvironmentResponse, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).GetQueueEnvironment(ctx, getQueueEnvironmentInput(data))
 	if err != nil {
+		if tfresource.NotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("%s not found, removing it from the state", r.typeName()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s, got error: %s", r.typeName(), err))
 		return
 	}
@@ -145,6 +194,14 @@ func (r *QueueEnvironmentResource) Read(ctx context.Context, req resource.ReadRe
 }
 
 func (r *QueueEnvironmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "QueueEnvironmentResource.Update")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("update", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data QueueEnvironmentResourceModel
 
 	// Read Terraform plan data into the model
@@ -153,8 +210,9 @@ func (r *QueueEnvironmentResource) Update(ctx context.Context, req resource.Upda
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	templateType := dltypes.EnvironmentTemplateTypeJson
-	switch data.TemplateType.ValueString() {
+	switch strings.ToLower(data.TemplateType.ValueString()) {
 	case "json":
 		templateType = dltypes.EnvironmentTemplateTypeJson
 	case "yaml":
@@ -164,10 +222,11 @@ func (r *QueueEnvironmentResource) Update(ctx context.Context, req resource.Upda
 	updateRequest := deadline.UpdateQueueEnvironmentInput{
 		QueueEnvironmentId: data.ID.ValueStringPointer(),
 		FarmId:             data.FarmId.ValueStringPointer(),
+		QueueId:            data.QueueId.ValueStringPointer(),
 		Template:           data.Template.ValueStringPointer(),
 		TemplateType:       templateType,
 	}
-	_, err := r.client.UpdateQueueEnvironment(ctx, &updateRequest)
+	_, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).UpdateQueueEnvironment(ctx, &updateRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update %s, got error: %s", r.typeName(), err))
 		return
@@ -177,6 +236,14 @@ func (r *QueueEnvironmentResource) Update(ctx context.Context, req resource.Upda
 }
 
 func (r *QueueEnvironmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "QueueEnvironmentResource.Delete")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("delete", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data QueueEnvironmentResourceModel
 
 	// Read Terraform prior state data into the model
@@ -185,20 +252,53 @@ func (r *QueueEnvironmentResource) Delete(ctx context.Context, req resource.Dele
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	deleteResourceRequest := &deadline.DeleteQueueEnvironmentInput{
+		FarmId:             data.FarmId.ValueStringPointer(),
+		QueueId:            data.QueueId.ValueStringPointer(),
 		QueueEnvironmentId: data.ID.ValueStringPointer(),
 	}
-	_, err := r.client.DeleteQueueEnvironment(ctx, deleteResourceRequest)
-	if err != nil {
+	_, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).DeleteQueueEnvironment(ctx, deleteResourceRequest)
+	// A resource that is already gone is deleted.
+	if err != nil && !tfresource.NotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", r.typeName(), err))
 		return
 	}
 }
 
+func (r *QueueEnvironmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		return
+	}
+	r.client.ModifyPlanRegion(ctx, req, resp)
+	r.client.ModifyPlanFarmID(ctx, req, resp)
+}
+
 func (r *QueueEnvironmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	tfresource.ImportCompositeID(ctx, req, resp, "farm_id", "queue_id", "id")
+}
+
+// getQueueEnvironmentInput returns the request that reads the queue
+// environment. The farm and queue IDs are part of the import ID, so an
+// imported queue environment can be read.
+func getQueueEnvironmentInput(data QueueEnvironmentResourceModel) *deadline.GetQueueEnvironmentInput {
+	return &deadline.GetQueueEnvironmentInput{
+		FarmId:             data.FarmId.ValueStringPointer(),
+		QueueId:            data.QueueId.ValueStringPointer(),
+		QueueEnvironmentId: data.ID.ValueStringPointer(),
+	}
 }
 
 func (r *QueueEnvironmentResource) typeName() string {
 	return "deadline_queue_environment"
 }
+
+// logFields sets the identifiers of the queue environment as fields of the log lines.
+func (r *QueueEnvironmentResource) logFields(ctx context.Context, data QueueEnvironmentResourceModel) context.Context {
+	return conns.LogFields(ctx, map[string]types.String{
+		"farm_id":              data.FarmId,
+		"queue_id":             data.QueueId,
+		"queue_environment_id": data.ID,
+	})
+}
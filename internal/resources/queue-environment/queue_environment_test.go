@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package queue_environment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestImportStateReadRequest(t *testing.T) {
+	ctx := context.Background()
+	r := &QueueEnvironmentResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "farm-0123/queue-4567/queueenv-89ab"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ImportState() diagnostics = %v", resp.Diagnostics)
+	}
+	var data QueueEnvironmentResourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("State.Get() diagnostics = %v", diags)
+	}
+
+	input := getQueueEnvironmentInput(data)
+	if got := aws.ToString(input.FarmId); got != "farm-0123" {
+		t.Errorf("FarmId = %q, want farm-0123", got)
+	}
+	if got := aws.ToString(input.QueueId); got != "queue-4567" {
+		t.Errorf("QueueId = %q, want queue-4567", got)
+	}
+	if got := aws.ToString(input.QueueEnvironmentId); got != "queueenv-89ab" {
+		t.Errorf("QueueEnvironmentId = %q, want queueenv-89ab", got)
+	}
+}
@@ -6,11 +6,21 @@ package queue
 import (
 	"context"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/deadline"
 	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/conns"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tags"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tfresource"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tracing"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/verify"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -18,6 +28,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &QueueResource{}
 var _ resource.ResourceWithImportState = &QueueResource{}
+var _ resource.ResourceWithModifyPlan = &QueueResource{}
 
 func New() resource.Resource {
 	return &QueueResource{
@@ -28,7 +39,7 @@ func New() resource.Resource {
 
 // QueueResource defines the resource implementation.
 type QueueResource struct {
-	client               *deadline.Client
+	client               *conns.AWSClient
 	resourceParentPrefix string
 	resourceTypeName     string
 }
@@ -78,6 +89,8 @@ type QueueResourceJobRunAsUserModel struct {
 
 // QueueResourceModel describes the resource data model.
 type QueueResourceModel struct {
+	Arn                             types.String                             `tfsdk:"arn"`
+	Region                          types.String                             `tfsdk:"region"`
 	DisplayName                     types.String                             `tfsdk:"display_name"`
 	Description                     types.String                             `tfsdk:"description"`
 	FarmId                          types.String                             `tfsdk:"farm_id"`
@@ -88,7 +101,8 @@ type QueueResourceModel struct {
 	JobAttachmentSettings           *QueueResourceJobAttachmentSettingsModel `tfsdk:"job_attachment_settings"`
 	JobRunAsUser                    *QueueResourceJobRunAsUserModel          `tfsdk:"job_run_as_user"`
 	RequiredFileSystemLocationNames []types.String                           `tfsdk:"required_file_system_location_names"`
-	Tags                            *types.MapType                           `tfsdk:"tags"`
+	Tags                            types.Map                                `tfsdk:"tags"`
+	TagsAll                         types.Map                                `tfsdk:"tags_all"`
 }
 
 func (r *QueueResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -132,6 +146,9 @@ func (r *QueueResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							"password_arn": schema.StringAttribute{
 								Optional:    true,
 								Description: "The password ARN for the user to run the job as.",
+								Validators: []validator.String{
+									verify.ARN("secretsmanager"),
+								},
 							},
 							"user": schema.StringAttribute{
 								Optional:    true,
@@ -142,11 +159,26 @@ func (r *QueueResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					"run_as": schema.StringAttribute{
 						Optional:    true,
 						Description: "The user to run the job as. Either QUEUE_CONFIGURED_USER or WORKER_AGENT_USER.",
+						Validators: []validator.String{
+							verify.Enum[dltypes.RunAs](),
+						},
 					},
 				},
 			},
 		},
 		Attributes: map[string]schema.Attribute{
+			"arn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ARN of the queue.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The AWS region of the resource. Defaults to the provider region. Changing it replaces the resource.",
+				Optional:            true,
+				Computed:            true,
+			},
 			"display_name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The display name of the queue.",
@@ -156,12 +188,20 @@ func (r *QueueResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "The description of the queue.",
 			},
 			"farm_id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The ID of the farm.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the farm. Defaults to the provider `default_farm_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"default_budget_action": schema.StringAttribute{
 				Optional:    true,
 				Description: "The default budget action for the queue. Valid values are: 'NONE', 'STOP_SCHEDULING_AND_COMPLETE_TASKS', and 'STOP_SCHEDULING_AND_CANCEL_TASKS'.",
+				Validators: []validator.String{
+					verify.Enum[dltypes.DefaultQueueBudgetAction](),
+				},
 			},
 			"allowed_storage_profile_ids": schema.ListAttribute{
 				ElementType:         types.StringType,
@@ -176,17 +216,26 @@ func (r *QueueResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"role_arn": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "The IAM role ARN that workers will use while running jobs for this queue.",
+				Validators: []validator.String{
+					verify.ARN("iam"),
+				},
 			},
 			"tags": schema.MapAttribute{
-				ElementType: types.MapType{
-					ElemType: types.StringType,
-				},
+				ElementType:         types.StringType,
 				Optional:            true,
 				MarkdownDescription: "The tags to apply to the queue.",
 			},
+			"tags_all": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "The tags of the queue, including the provider default tags.",
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the queue.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -198,12 +247,12 @@ func (r *QueueResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*deadline.Client)
+	client, ok := req.ProviderData.(*conns.AWSClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *conns.AWSClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -213,6 +262,14 @@ func (r *QueueResource) Configure(ctx context.Context, req resource.ConfigureReq
 }
 
 func (r *QueueResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "QueueResource.Create")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("create", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data QueueResourceModel
 
 	// Read Terraform plan data into the model
@@ -220,12 +277,20 @@ func (r *QueueResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 
+	resourceTags, diags := tags.FromValue(ctx, data.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	allTags := r.client.IgnoreTagsConfig.Filter(r.client.DefaultTagsConfig.MergeTags(resourceTags))
 	createRequest := &deadline.CreateQueueInput{
 		FarmId:      data.FarmId.ValueStringPointer(),
 		DisplayName: data.DisplayName.ValueStringPointer(),
 		Description: data.Description.ValueStringPointer(),
 		RoleArn:     data.RoleArn.ValueStringPointer(),
+		Tags:        allTags,
 	}
 	if len(data.AllowedStorageProfileIds) > 0 {
 		allowedStorageProfileIds := make([]string, len(data.AllowedStorageProfileIds))
@@ -275,18 +340,30 @@ func (r *QueueResource) Create(ctx context.Context, req resource.CreateRequest,
 			}
 		}
 	}
-	createOutput, err := r.client.CreateQueue(ctx, createRequest)
+	createOutput, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).CreateQueue(ctx, createRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create %s, %s, got error: %s", r.typeName(), data.DisplayName.String(), err))
 		return
 	}
 	data.ID = types.StringValue(*createOutput.QueueId)
+	ctx = r.logFields(ctx, data)
+	queueARN, err := r.client.DeadlineARN(ctx, data.Region.ValueString(), r.resourceARN(data))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s ARN, got error: %s", r.typeName(), err))
+		return
+	}
+	data.Arn = types.StringValue(queueARN)
+	data.TagsAll, diags = tags.ToValue(ctx, allTags)
+	resp.Diagnostics.Append(diags...)
 	tflog.Trace(ctx, fmt.Sprintf("created %s", r.typeName()))
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *QueueResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "QueueResource.Read")
+	defer tracing.End(span, &resp.Diagnostics)
+
 	var data QueueResourceModel
 
 	// Read Terraform prior state data into the model
@@ -295,38 +372,79 @@ func (r *QueueResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	getResponse, err := r.client.GetQueue(ctx, &deadline.GetQueueInput{
+	ctx = r.logFields(ctx, data)
+	// State written before the region attribute existed has no region.
+	if data.Region.IsNull() {
+		data.Region = types.StringValue(r.client.Region)
+	}
+	conn := r.client.DeadlineClientForRegion(data.Region.ValueString())
+	getResponse, err := conn.GetQueue(ctx, &deadline.GetQueueInput{
 		QueueId: data.ID.ValueStringPointer(),
 		FarmId:  data.FarmId.ValueStringPointer(),
 	})
 	if err != nil {
+		if tfresource.NotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("%s not found, removing it from the state", r.typeName()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s, got error: %s", r.typeName(), err))
 		return
 	}
-	data.Description = types.StringValue(*getResponse.Description)
+	data.Description = types.StringPointerValue(getResponse.Description)
 	data.DisplayName = types.StringValue(*getResponse.DisplayName)
 	data.FarmId = types.StringValue(*getResponse.FarmId)
-	if getResponse.AllowedStorageProfileIds != nil {
-		data.RoleArn = types.StringValue(*getResponse.RoleArn)
+	data.RoleArn = types.StringPointerValue(getResponse.RoleArn)
+	data.AllowedStorageProfileIds = nil
+	for _, id := range getResponse.AllowedStorageProfileIds {
+		data.AllowedStorageProfileIds = append(data.AllowedStorageProfileIds, types.StringValue(id))
 	}
 	if getResponse.JobAttachmentSettings != nil {
-
-		data.JobAttachmentSettings.RootPrefix = types.StringValue(*getResponse.JobAttachmentSettings.RootPrefix)
-		data.JobAttachmentSettings.S3BucketName = types.StringValue(*getResponse.JobAttachmentSettings.S3BucketName)
+		// The state of an imported queue has no job attachment settings yet.
+		if data.JobAttachmentSettings == nil {
+			data.JobAttachmentSettings = &QueueResourceJobAttachmentSettingsModel{}
+		}
+		data.JobAttachmentSettings.RootPrefix = types.StringPointerValue(getResponse.JobAttachmentSettings.RootPrefix)
+		data.JobAttachmentSettings.S3BucketName = types.StringPointerValue(getResponse.JobAttachmentSettings.S3BucketName)
+	}
+	queueARN, err := r.client.DeadlineARN(ctx, data.Region.ValueString(), r.resourceARN(data))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s ARN, got error: %s", r.typeName(), err))
+		return
 	}
+	data.Arn = types.StringValue(queueARN)
+	remoteTags, err := tags.List(ctx, conn, queueARN)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s tags, got error: %s", r.typeName(), err))
+		return
+	}
+	var diags diag.Diagnostics
+	data.Tags, data.TagsAll, diags = tags.Flatten(ctx, r.client.DefaultTagsConfig, r.client.IgnoreTagsConfig, data.Tags, remoteTags)
+	resp.Diagnostics.Append(diags...)
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *QueueResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data QueueResourceModel
+	ctx, span := tracing.Start(ctx, "QueueResource.Update")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("update", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data, state QueueResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
+	data.Arn = state.Arn
 	updateRequest := &deadline.UpdateQueueInput{
 		FarmId:      data.FarmId.ValueStringPointer(),
 		Description: data.Description.ValueStringPointer(),
@@ -379,11 +497,23 @@ func (r *QueueResource) Update(ctx context.Context, req resource.UpdateRequest,
 			}
 		}
 	}
-	_, err := r.client.UpdateQueue(ctx, updateRequest)
+	conn := r.client.DeadlineClientForRegion(data.Region.ValueString())
+	_, err := conn.UpdateQueue(ctx, updateRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update %s, got error: %s", r.typeName(), err))
 		return
 	}
+	if !data.TagsAll.Equal(state.TagsAll) {
+		queueARN, err := r.client.DeadlineARN(ctx, data.Region.ValueString(), r.resourceARN(data))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s ARN, got error: %s", r.typeName(), err))
+			return
+		}
+		resp.Diagnostics.Append(tags.UpdateValues(ctx, conn, r.client.IgnoreTagsConfig, queueARN, state.TagsAll, data.TagsAll)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 	data.Description = types.StringValue(*updateRequest.Description)
 	data.DisplayName = types.StringValue(*updateRequest.DisplayName)
 	// Save updated data into Terraform state
@@ -391,6 +521,14 @@ func (r *QueueResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *QueueResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "QueueResource.Delete")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("delete", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data QueueResourceModel
 
 	// Read Terraform prior state data into the model
@@ -399,21 +537,73 @@ func (r *QueueResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	deleteResourceRequest := &deadline.DeleteQueueInput{
 		QueueId: data.ID.ValueStringPointer(),
 		FarmId:  data.FarmId.ValueStringPointer(),
 	}
-	_, err := r.client.DeleteQueue(ctx, deleteResourceRequest)
-	if err != nil {
+	_, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).DeleteQueue(ctx, deleteResourceRequest)
+	// A resource that is already gone is deleted.
+	if err != nil && !tfresource.NotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", r.typeName(), err))
 		return
 	}
 }
 
+func (r *QueueResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		return
+	}
+	r.client.ModifyPlanRegion(ctx, req, resp)
+	r.client.ModifyPlanFarmID(ctx, req, resp)
+	tags.ModifyPlan(ctx, r.client.DefaultTagsConfig, r.client.IgnoreTagsConfig, req, resp)
+}
+
 func (r *QueueResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	tfresource.ImportCompositeIDOrName(ctx, req, resp, r.idsByName, "farm_id", "id")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Imported resources, including the ones found by display name, are
+	// looked up in the provider region.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("region"), r.client.Region)...)
 }
 
 func (r *QueueResource) typeName() string {
 	return fmt.Sprintf("%s_%s", r.resourceParentPrefix, r.resourceTypeName)
 }
+
+// resourceARN returns the resource part of the queue ARN.
+func (r *QueueResource) resourceARN(data QueueResourceModel) string {
+	return fmt.Sprintf("farm/%s/queue/%s", data.FarmId.ValueString(), data.ID.ValueString())
+}
+
+// logFields sets the identifiers of the queue as fields of the log lines.
+func (r *QueueResource) logFields(ctx context.Context, data QueueResourceModel) context.Context {
+	return conns.LogFields(ctx, map[string]types.String{
+		"farm_id":  data.FarmId,
+		"queue_id": data.ID,
+	})
+}
+
+// idsByName returns the IDs of the queues of the farm parents[0] named name,
+// in the provider region.
+func (r *QueueResource) idsByName(ctx context.Context, parents []string, name string) ([]string, error) {
+	var ids []string
+	paginator := deadline.NewListQueuesPaginator(r.client.DeadlineClient, &deadline.ListQueuesInput{
+		FarmId: aws.String(parents[0]),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, queue := range page.Queues {
+			if aws.ToString(queue.DisplayName) == name {
+				ids = append(ids, aws.ToString(queue.QueueId))
+			}
+		}
+	}
+	return ids, nil
+}
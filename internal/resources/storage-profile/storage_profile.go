@@ -6,21 +6,30 @@ package storageprofile
 import (
 	"context"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/deadline"
 	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/conns"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tfresource"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tracing"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/verify"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strings"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &StorageProfileResource{}
 var _ resource.ResourceWithImportState = &StorageProfileResource{}
+var _ resource.ResourceWithModifyPlan = &StorageProfileResource{}
 
 func New() resource.Resource {
 	return &StorageProfileResource{}
@@ -28,17 +37,18 @@ func New() resource.Resource {
 
 // StorageProfileResource defines the resource implementation.
 type StorageProfileResource struct {
-	client *deadline.Client
+	client *conns.AWSClient
 }
 
 type StorageProfileFileSystemLocations struct {
-	Name types.String `tfschema:"name"`
-	Path types.String `tfschema:"path"`
-	Type types.String `tfschema:"type"`
+	Name types.String `tfsdk:"name"`
+	Path types.String `tfsdk:"path"`
+	Type types.String `tfsdk:"type"`
 }
 
 // StorageProfileResourceModel describes the resource data model.
 type StorageProfileResourceModel struct {
+	Region              types.String                         `tfsdk:"region"`
 	DisplayName         types.String                         `tfsdk:"display_name"`
 	FarmId              types.String                         `tfsdk:"farm_id"`
 	OSFamily            types.String                         `tfsdk:"os_family"`
@@ -72,28 +82,50 @@ func (r *StorageProfileResource) Schema(ctx context.Context, req resource.Schema
 						"type": schema.StringAttribute{
 							MarkdownDescription: "Type of the file system location. Can be either local, or shared",
 							Required:            true,
+							Validators: []validator.String{
+								verify.EnumCaseInsensitive[dltypes.FileSystemLocationType](),
+							},
 						},
 					},
 				},
 			},
 		},
 		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The AWS region of the resource. Defaults to the provider region. Changing it replaces the resource.",
+				Optional:            true,
+				Computed:            true,
+			},
 			"display_name": schema.StringAttribute{
 				MarkdownDescription: "The display name of the storage profile.",
 				Required:            true,
 			},
 			"farm_id": schema.StringAttribute{
-				MarkdownDescription: "The deadline farm associated with the storage profile.",
-				Required:            true,
+				MarkdownDescription: "The deadline farm associated with the storage profile. Defaults to the provider `default_farm_id`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"os_family": schema.StringAttribute{
 				MarkdownDescription: "The OS family of the storage profile. Can be: windows, linux or macos",
 				Required:            true,
+				Validators: []validator.String{
+					verify.EnumCaseInsensitive[dltypes.StorageProfileOperatingSystemFamily](),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the storage profile.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -105,12 +137,12 @@ func (r *StorageProfileResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*deadline.Client)
+	client, ok := req.ProviderData.(*conns.AWSClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *conns.AWSClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -121,7 +153,7 @@ func (r *StorageProfileResource) Configure(ctx context.Context, req resource.Con
 
 func determineOsProfile(inputOS string) dltypes.StorageProfileOperatingSystemFamily {
 	osFamily := dltypes.StorageProfileOperatingSystemFamilyWindows
-	switch inputOS {
+	switch strings.ToLower(inputOS) {
 	case "macos":
 		osFamily = dltypes.StorageProfileOperatingSystemFamilyMacos
 	case "windows":
@@ -132,12 +164,41 @@ func determineOsProfile(inputOS string) dltypes.StorageProfileOperatingSystemFam
 	return osFamily
 }
 
+// flattenOSFamily returns the OS family reported by Deadline. os_family is
+// validated case-insensitively, so prior is kept when it only differs in case
+// to avoid replacing the storage profile.
+func flattenOSFamily(prior types.String, osFamily dltypes.StorageProfileOperatingSystemFamily) types.String {
+	if strings.EqualFold(prior.ValueString(), string(osFamily)) {
+		return prior
+	}
+	return types.StringValue(string(osFamily))
+}
+
+// flattenFileSystemLocations returns the file system locations reported by
+// Deadline. Like os_family, the type of a location keeps its prior case when
+// only the case differs.
+func flattenFileSystemLocations(prior []*StorageProfileFileSystemLocations, locations []dltypes.FileSystemLocation) []*StorageProfileFileSystemLocations {
+	var flattened []*StorageProfileFileSystemLocations
+	for i, loc := range locations {
+		locationType := types.StringValue(string(loc.Type))
+		if i < len(prior) && prior[i] != nil && strings.EqualFold(prior[i].Type.ValueString(), string(loc.Type)) {
+			locationType = prior[i].Type
+		}
+		flattened = append(flattened, &StorageProfileFileSystemLocations{
+			Name: types.StringPointerValue(loc.Name),
+			Path: types.StringPointerValue(loc.Path),
+			Type: locationType,
+		})
+	}
+	return flattened
+}
+
 func getFileSystemLocations(diags diag.Diagnostics, data StorageProfileResourceModel) []dltypes.FileSystemLocation {
 	var locations []dltypes.FileSystemLocation
 	if len(data.FileSystemLocations) > 0 {
 		for _, loc := range data.FileSystemLocations {
 			cl := dltypes.FileSystemLocation{}
-			switch cl.Type {
+			switch strings.ToLower(loc.Type.ValueString()) {
 			case "local":
 				cl.Type = dltypes.FileSystemLocationTypeLocal
 			case "shared":
@@ -154,6 +215,14 @@ func getFileSystemLocations(diags diag.Diagnostics, data StorageProfileResourceM
 }
 
 func (r *StorageProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "StorageProfileResource.Create")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("create", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data StorageProfileResourceModel
 
 	// Read Terraform plan data into the model
@@ -162,7 +231,8 @@ func (r *StorageProfileResource) Create(ctx context.Context, req resource.Create
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	osFamily := determineOsProfile(data.OSFamily.String())
+	ctx = r.logFields(ctx, data)
+	osFamily := determineOsProfile(data.OSFamily.ValueString())
 	fSystemLocations := getFileSystemLocations(resp.Diagnostics, data)
 	if resp.Diagnostics.HasError() {
 		return
@@ -173,18 +243,22 @@ func (r *StorageProfileResource) Create(ctx context.Context, req resource.Create
 		OsFamily:            osFamily,
 		FileSystemLocations: fSystemLocations,
 	}
-	storageprofileOutput, err := r.client.CreateStorageProfile(ctx, &storageprofileRequest)
+	storageprofileOutput, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).CreateStorageProfile(ctx, &storageprofileRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create %s, %s, got error: %s", r.typeName(), data.DisplayName.String(), err))
 		return
 	}
 	data.ID = types.StringValue(*storageprofileOutput.StorageProfileId)
+	ctx = r.logFields(ctx, data)
 	tflog.Trace(ctx, fmt.Sprintf("created %s", r.typeName()))
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *StorageProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "StorageProfileResource.Read")
+	defer tracing.End(span, &resp.Diagnostics)
+
 	var data StorageProfileResourceModel
 
 	// Read Terraform prior state data into the model
@@ -193,17 +267,27 @@ func (r *StorageProfileResource) Read(ctx context.Context, req resource.ReadRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	storageprofileResponse, err := r.client.GetStorageProfile(ctx, &deadline.GetStorageProfileInput{
+	ctx = r.logFields(ctx, data)
+	// State written before the region attribute existed has no region.
+	if data.Region.IsNull() {
+		data.Region = types.StringValue(r.client.Region)
+	}
+	storageprofileResponse, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).GetStorageProfile(ctx, &deadline.GetStorageProfileInput{
+		FarmId:           data.FarmId.ValueStringPointer(),
 		StorageProfileId: data.ID.ValueStringPointer(),
 	})
 	if err != nil {
+		if tfresource.NotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("%s not found, removing it from the state", r.typeName()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s, got error: %s", r.typeName(), err))
 		return
 	}
 	data.ID = types.StringValue(*storageprofileResponse.StorageProfileId)
-	if len(storageprofileResponse.OsFamily.Values()) > 0 {
-		data.OSFamily = types.StringValue(fmt.Sprintf("%v", storageprofileResponse.OsFamily))
-	}
+	data.OSFamily = flattenOSFamily(data.OSFamily, storageprofileResponse.OsFamily)
+	data.FileSystemLocations = flattenFileSystemLocations(data.FileSystemLocations, storageprofileResponse.FileSystemLocations)
 	if storageprofileResponse.DisplayName != nil {
 		data.DisplayName = types.StringValue(*storageprofileResponse.DisplayName)
 	}
@@ -212,6 +296,14 @@ func (r *StorageProfileResource) Read(ctx context.Context, req resource.ReadRequ
 }
 
 func (r *StorageProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "StorageProfileResource.Update")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("update", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data StorageProfileResourceModel
 
 	// Read Terraform plan data into the model
@@ -220,14 +312,15 @@ func (r *StorageProfileResource) Update(ctx context.Context, req resource.Update
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	osFamily := determineOsProfile(data.OSFamily.String())
+	ctx = r.logFields(ctx, data)
+	osFamily := determineOsProfile(data.OSFamily.ValueString())
 	updateRequest := deadline.UpdateStorageProfileInput{
 		StorageProfileId: data.ID.ValueStringPointer(),
 		FarmId:           data.FarmId.ValueStringPointer(),
 		DisplayName:      data.DisplayName.ValueStringPointer(),
 		OsFamily:         osFamily,
 	}
-	_, err := r.client.UpdateStorageProfile(ctx, &updateRequest)
+	_, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).UpdateStorageProfile(ctx, &updateRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update %s, got error: %s", r.typeName(), err))
 		return
@@ -238,6 +331,14 @@ func (r *StorageProfileResource) Update(ctx context.Context, req resource.Update
 }
 
 func (r *StorageProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "StorageProfileResource.Delete")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.client.CheckWritable("delete", r.typeName())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data StorageProfileResourceModel
 
 	// Read Terraform prior state data into the model
@@ -246,20 +347,67 @@ func (r *StorageProfileResource) Delete(ctx context.Context, req resource.Delete
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = r.logFields(ctx, data)
 	deleteResourceRequest := &deadline.DeleteStorageProfileInput{
+		FarmId:           data.FarmId.ValueStringPointer(),
 		StorageProfileId: data.ID.ValueStringPointer(),
 	}
-	_, err := r.client.DeleteStorageProfile(ctx, deleteResourceRequest)
-	if err != nil {
+	_, err := r.client.DeadlineClientForRegion(data.Region.ValueString()).DeleteStorageProfile(ctx, deleteResourceRequest)
+	// A resource that is already gone is deleted.
+	if err != nil && !tfresource.NotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", r.typeName(), err))
 		return
 	}
 }
 
+func (r *StorageProfileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		return
+	}
+	r.client.ModifyPlanRegion(ctx, req, resp)
+	r.client.ModifyPlanFarmID(ctx, req, resp)
+}
+
 func (r *StorageProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	tfresource.ImportCompositeIDOrName(ctx, req, resp, r.idsByName, "farm_id", "id")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Imported resources, including the ones found by display name, are
+	// looked up in the provider region.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("region"), r.client.Region)...)
 }
 
 func (r *StorageProfileResource) typeName() string {
 	return "deadline_storage_profile"
 }
+
+// logFields sets the identifiers of the storage profile as fields of the log lines.
+func (r *StorageProfileResource) logFields(ctx context.Context, data StorageProfileResourceModel) context.Context {
+	return conns.LogFields(ctx, map[string]types.String{
+		"farm_id":            data.FarmId,
+		"storage_profile_id": data.ID,
+	})
+}
+
+// idsByName returns the IDs of the storage profiles of the farm parents[0]
+// named name.
+func (r *StorageProfileResource) idsByName(ctx context.Context, parents []string, name string) ([]string, error) {
+	var ids []string
+	paginator := deadline.NewListStorageProfilesPaginator(r.client.DeadlineClient, &deadline.ListStorageProfilesInput{
+		FarmId: aws.String(parents[0]),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, storageProfile := range page.StorageProfiles {
+			if aws.ToString(storageProfile.DisplayName) == name {
+				ids = append(ids, aws.ToString(storageProfile.StorageProfileId))
+			}
+		}
+	}
+	return ids, nil
+}
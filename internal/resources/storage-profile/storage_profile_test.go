@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storageprofile
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOSFamilyRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		prior    types.String
+		reported dltypes.StorageProfileOperatingSystemFamily
+		want     types.String
+	}{
+		{name: "lowercase", prior: types.StringValue("linux"), want: types.StringValue("linux")},
+		{name: "mixed case", prior: types.StringValue("MacOS"), want: types.StringValue("MacOS")},
+		{name: "uppercase", prior: types.StringValue("WINDOWS"), want: types.StringValue("WINDOWS")},
+		{name: "changed outside terraform", prior: types.StringValue("linux"), reported: dltypes.StorageProfileOperatingSystemFamilyWindows, want: types.StringValue("WINDOWS")},
+		{name: "imported", prior: types.StringNull(), reported: dltypes.StorageProfileOperatingSystemFamilyLinux, want: types.StringValue("LINUX")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Without a reported value, Deadline reports what Create sent.
+			reported := tt.reported
+			if reported == "" {
+				reported = determineOsProfile(tt.prior.ValueString())
+			}
+			if got := flattenOSFamily(tt.prior, reported); !got.Equal(tt.want) {
+				t.Errorf("flattenOSFamily(%s, %s) = %s, want %s", tt.prior, reported, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlattenFileSystemLocations(t *testing.T) {
+	locations := []dltypes.FileSystemLocation{
+		{Name: aws.String("projects"), Path: aws.String("/mnt/projects"), Type: dltypes.FileSystemLocationTypeShared},
+		{Name: aws.String("scratch"), Path: aws.String("/tmp/scratch"), Type: dltypes.FileSystemLocationTypeLocal},
+	}
+	tests := []struct {
+		name  string
+		prior []*StorageProfileFileSystemLocations
+		want  []string
+	}{
+		{name: "imported", want: []string{"SHARED", "LOCAL"}},
+		{
+			name: "configured in lowercase",
+			prior: []*StorageProfileFileSystemLocations{
+				{Name: types.StringValue("projects"), Path: types.StringValue("/mnt/projects"), Type: types.StringValue("shared")},
+				{Name: types.StringValue("scratch"), Path: types.StringValue("/tmp/scratch"), Type: types.StringValue("shared")},
+			},
+			want: []string{"shared", "LOCAL"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flattenFileSystemLocations(tt.prior, locations)
+			if len(got) != len(locations) {
+				t.Fatalf("flattenFileSystemLocations() returned %d locations, want %d", len(got), len(locations))
+			}
+			for i, loc := range got {
+				if loc.Name.ValueString() != aws.ToString(locations[i].Name) || loc.Path.ValueString() != aws.ToString(locations[i].Path) {
+					t.Errorf("location %d = %s %s, want %s %s", i, loc.Name, loc.Path, aws.ToString(locations[i].Name), aws.ToString(locations[i].Path))
+				}
+				if loc.Type.ValueString() != tt.want[i] {
+					t.Errorf("location %d type = %s, want %s", i, loc.Type, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/deadline"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sort"
+	"strings"
+)
+
+// DefaultConfig holds the tags of the provider default_tags block.
+type DefaultConfig struct {
+	Tags map[string]string
+}
+
+// IgnoreConfig holds the tag keys of the provider ignore_tags block. Matching
+// tags are managed outside Terraform and never read into state or removed.
+type IgnoreConfig struct {
+	Keys        []string
+	KeyPrefixes []string
+}
+
+// Ignored reports whether the tag key matches the ignore configuration.
+func (c *IgnoreConfig) Ignored(key string) bool {
+	if c == nil {
+		return false
+	}
+	for _, k := range c.Keys {
+		if key == k {
+			return true
+		}
+	}
+	for _, prefix := range c.KeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the tags without the ignored keys.
+func (c *IgnoreConfig) Filter(tags map[string]string) map[string]string {
+	result := make(map[string]string)
+	for k, v := range tags {
+		if !c.Ignored(k) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// MergeTags returns the default tags overridden by the given resource tags.
+func (c *DefaultConfig) MergeTags(resourceTags map[string]string) map[string]string {
+	result := make(map[string]string)
+	if c != nil {
+		for k, v := range c.Tags {
+			result[k] = v
+		}
+	}
+	for k, v := range resourceTags {
+		result[k] = v
+	}
+	return result
+}
+
+// ResourceTags derives the resource tags from the tags present on the remote
+// resource. Tags that match a default tag are left out unless they were part of
+// the prior resource tags.
+func (c *DefaultConfig) ResourceTags(prior, remote map[string]string) map[string]string {
+	result := make(map[string]string)
+	for k, v := range remote {
+		if _, ok := prior[k]; !ok && c != nil {
+			if defaultValue, ok := c.Tags[k]; ok && defaultValue == v {
+				continue
+			}
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// FromValue converts a tags map attribute into a plain map.
+func FromValue(ctx context.Context, value types.Map) (map[string]string, diag.Diagnostics) {
+	result := make(map[string]string)
+	if value.IsNull() || value.IsUnknown() {
+		return result, nil
+	}
+	diags := value.ElementsAs(ctx, &result, false)
+	return result, diags
+}
+
+// ToValue converts a plain map into a tags map attribute.
+func ToValue(ctx context.Context, tags map[string]string) (types.Map, diag.Diagnostics) {
+	return types.MapValueFrom(ctx, types.StringType, tags)
+}
+
+// Flatten computes the tags and tags_all attributes from the tags read back
+// from the remote resource. An empty tags attribute stays null when it was
+// null before, so that omitting tags does not produce a diff. Ignored tags are
+// left out of both attributes.
+func Flatten(ctx context.Context, defaults *DefaultConfig, ignore *IgnoreConfig, prior types.Map, remote map[string]string) (types.Map, types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	remote = ignore.Filter(remote)
+	priorTags, d := FromValue(ctx, prior)
+	diags.Append(d...)
+	resourceTags := defaults.ResourceTags(priorTags, remote)
+
+	tagsValue := types.MapNull(types.StringType)
+	if len(resourceTags) > 0 || !prior.IsNull() {
+		tagsValue, d = ToValue(ctx, resourceTags)
+		diags.Append(d...)
+	}
+	tagsAllValue, d := ToValue(ctx, remote)
+	diags.Append(d...)
+	return tagsValue, tagsAllValue, diags
+}
+
+// ModifyPlan sets the planned tags_all attribute to the resource tags merged
+// with the provider default tags, without the ignored tags.
+func ModifyPlan(ctx context.Context, defaults *DefaultConfig, ignore *IgnoreConfig, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var planTags types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &planTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if planTags.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))...)
+		return
+	}
+	resourceTags, diags := FromValue(ctx, planTags)
+	resp.Diagnostics.Append(diags...)
+	tagsAll, diags := ToValue(ctx, ignore.Filter(defaults.MergeTags(resourceTags)))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+}
+
+// List returns the tags of the Deadline resource identified by resourceARN.
+func List(ctx context.Context, conn *deadline.Client, resourceARN string) (map[string]string, error) {
+	output, err := conn.ListTagsForResource(ctx, &deadline.ListTagsForResourceInput{
+		ResourceArn: &resourceARN,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing tags for %s: %w", resourceARN, err)
+	}
+	if output.Tags == nil {
+		return make(map[string]string), nil
+	}
+	return output.Tags, nil
+}
+
+// Update applies the difference between oldTags and newTags to the Deadline
+// resource identified by resourceARN.
+func Update(ctx context.Context, conn *deadline.Client, resourceARN string, oldTags, newTags map[string]string) error {
+	var removedKeys []string
+	for k := range oldTags {
+		if _, ok := newTags[k]; !ok {
+			removedKeys = append(removedKeys, k)
+		}
+	}
+	updatedTags := make(map[string]string)
+	for k, v := range newTags {
+		if oldValue, ok := oldTags[k]; !ok || oldValue != v {
+			updatedTags[k] = v
+		}
+	}
+
+	if len(removedKeys) > 0 {
+		sort.Strings(removedKeys)
+		_, err := conn.UntagResource(ctx, &deadline.UntagResourceInput{
+			ResourceArn: &resourceARN,
+			TagKeys:     removedKeys,
+		})
+		if err != nil {
+			return fmt.Errorf("untagging %s: %w", resourceARN, err)
+		}
+	}
+	if len(updatedTags) > 0 {
+		_, err := conn.TagResource(ctx, &deadline.TagResourceInput{
+			ResourceArn: &resourceARN,
+			Tags:        updatedTags,
+		})
+		if err != nil {
+			return fmt.Errorf("tagging %s: %w", resourceARN, err)
+		}
+	}
+	return nil
+}
+
+// UpdateValues applies the difference between two tags_all attribute values
+// to the Deadline resource identified by resourceARN. Ignored tags are never
+// tagged or untagged.
+func UpdateValues(ctx context.Context, conn *deadline.Client, ignore *IgnoreConfig, resourceARN string, oldValue, newValue types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+	oldTags, d := FromValue(ctx, oldValue)
+	diags.Append(d...)
+	newTags, d := FromValue(ctx, newValue)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	if err := Update(ctx, conn, resourceARN, ignore.Filter(oldTags), ignore.Filter(newTags)); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update tags, got error: %s", err))
+	}
+	return diags
+}
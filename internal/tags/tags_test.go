@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDefaultConfigMergeTags(t *testing.T) {
+	config := &DefaultConfig{
+		Tags: map[string]string{"owner": "render", "cost-center": "123"},
+	}
+	got := config.MergeTags(map[string]string{"cost-center": "456", "project": "vfx"})
+	want := map[string]string{"owner": "render", "cost-center": "456", "project": "vfx"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeTags() = %v, want %v", got, want)
+	}
+
+	var nilConfig *DefaultConfig
+	got = nilConfig.MergeTags(map[string]string{"project": "vfx"})
+	want = map[string]string{"project": "vfx"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeTags() on nil config = %v, want %v", got, want)
+	}
+}
+
+func TestDefaultConfigResourceTags(t *testing.T) {
+	config := &DefaultConfig{
+		Tags: map[string]string{"owner": "render", "cost-center": "123"},
+	}
+	testCases := map[string]struct {
+		prior  map[string]string
+		remote map[string]string
+		want   map[string]string
+	}{
+		"defaults only": {
+			remote: map[string]string{"owner": "render", "cost-center": "123"},
+			want:   map[string]string{},
+		},
+		"overridden default": {
+			prior:  map[string]string{"cost-center": "456"},
+			remote: map[string]string{"owner": "render", "cost-center": "456"},
+			want:   map[string]string{"cost-center": "456"},
+		},
+		"default repeated in resource tags": {
+			prior:  map[string]string{"owner": "render"},
+			remote: map[string]string{"owner": "render", "cost-center": "123"},
+			want:   map[string]string{"owner": "render"},
+		},
+		"out of band tag": {
+			remote: map[string]string{"owner": "render", "cost-center": "123", "manual": "yes"},
+			want:   map[string]string{"manual": "yes"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := config.ResourceTags(tc.prior, tc.remote)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ResourceTags() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIgnoreConfigFilter(t *testing.T) {
+	config := &IgnoreConfig{
+		Keys:        []string{"managed-by"},
+		KeyPrefixes: []string{"finops:"},
+	}
+	got := config.Filter(map[string]string{
+		"finops:budget": "gpu",
+		"managed-by":    "automation",
+		"owner":         "render",
+	})
+	want := map[string]string{"owner": "render"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfresource
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"strings"
+)
+
+// IDSeparator separates the parts of a composite ID. Deadline IDs contain
+// hyphens, so a hyphen cannot be used.
+const IDSeparator = "/"
+
+// CompositeID joins the parts of a composite ID, for example
+// "farm-0123/queue-0123/fleet-0123".
+func CompositeID(parts ...string) string {
+	return strings.Join(parts, IDSeparator)
+}
+
+// ParseCompositeID splits id into exactly len(names) non-empty parts. names
+// are the attributes the parts belong to; they describe the expected format
+// in the returned error.
+func ParseCompositeID(id string, names ...string) ([]string, error) {
+	parts := strings.Split(id, IDSeparator)
+	if len(parts) != len(names) {
+		return nil, fmt.Errorf("expected an ID in the format %s, got: %q", strings.Join(names, IDSeparator), id)
+	}
+	for i, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("expected an ID in the format %s, got an empty %s: %q", strings.Join(names, IDSeparator), names[i], id)
+		}
+	}
+	return parts, nil
+}
+
+// ImportCompositeID imports a resource whose ID is made of the top level
+// attributes names, in order, separated by IDSeparator. Each part of the
+// import ID is set on its attribute, so that Read finds the parent IDs it
+// needs.
+func ImportCompositeID(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, names ...string) {
+	parts, err := ParseCompositeID(req.ID, names...)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+	for i, name := range names {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(name), parts[i])...)
+	}
+}
+
+// NamePrefix marks the last part of an import ID as the display name of the
+// resource instead of its ID, for example "farm-0123/name:GPU Fleet".
+const NamePrefix = "name:"
+
+// NameFunc returns the IDs of the resources whose display name is name.
+// parents holds the IDs that precede the name in the import ID.
+type NameFunc func(ctx context.Context, parents []string, name string) ([]string, error)
+
+// ImportCompositeIDOrName imports a resource like ImportCompositeID, and also
+// accepts NamePrefix and a display name in place of the last part of the
+// import ID. byName resolves the display name, which must match exactly one
+// resource. The display name may itself contain IDSeparator.
+func ImportCompositeIDOrName(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, byName NameFunc, names ...string) {
+	parts := strings.SplitN(req.ID, IDSeparator, len(names))
+	last := len(names) - 1
+	if len(parts) != len(names) || !strings.HasPrefix(parts[last], NamePrefix) {
+		ImportCompositeID(ctx, req, resp, names...)
+		return
+	}
+	format := strings.Join(append(names[:last:last], NamePrefix+"<display_name>"), IDSeparator)
+	for i, part := range parts {
+		if part == "" || part == NamePrefix {
+			resp.Diagnostics.AddError("Unexpected Import Identifier",
+				fmt.Sprintf("expected an ID in the format %s, got an empty %s: %q", format, names[i], req.ID))
+			return
+		}
+	}
+	name := strings.TrimPrefix(parts[last], NamePrefix)
+	ids, err := byName(ctx, parts[:last], name)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find the resource named %q, got error: %s", name, err))
+		return
+	}
+	if len(ids) == 0 {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", fmt.Sprintf("No resource is named %q.", name))
+		return
+	}
+	if len(ids) > 1 {
+		resp.Diagnostics.AddError("Unexpected Import Identifier",
+			fmt.Sprintf("%d resources are named %q: %s. Import one of them by ID instead.", len(ids), name, strings.Join(ids, ", ")))
+		return
+	}
+	parts[last] = ids[0]
+	for i, attribute := range names {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(attribute), parts[i])...)
+	}
+}
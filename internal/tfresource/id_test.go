@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfresource
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParseCompositeID(t *testing.T) {
+	tests := []struct {
+		id      string
+		want    []string
+		wantErr string
+	}{
+		{id: "farm-0123/queue-4567", want: []string{"farm-0123", "queue-4567"}},
+		{id: "farm-0123", wantErr: "expected an ID in the format farm_id/id"},
+		{id: "farm-0123/queue-4567/fleet-89ab", wantErr: "expected an ID in the format farm_id/id"},
+		{id: "farm-0123/", wantErr: "got an empty id"},
+		{id: "farm-0123-queue-4567", wantErr: "expected an ID in the format farm_id/id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got, err := ParseCompositeID(tt.id, "farm_id", "id")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseCompositeID() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCompositeID() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCompositeID() = %v, want %v", got, tt.want)
+			}
+			if id := CompositeID(got...); id != tt.id {
+				t.Errorf("CompositeID() = %q, want %q", id, tt.id)
+			}
+		})
+	}
+}
+
+func TestImportCompositeIDOrName(t *testing.T) {
+	ctx := context.Background()
+	resourceSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"farm_id": schema.StringAttribute{Optional: true},
+			"id":      schema.StringAttribute{Computed: true},
+		},
+	}
+	byName := func(ctx context.Context, parents []string, name string) ([]string, error) {
+		if parents[0] != "farm-0123" {
+			return nil, nil
+		}
+		switch name {
+		case "GPU Fleet", "Render/GPU":
+			return []string{"fleet-4567"}, nil
+		case "CPU Fleet":
+			return []string{"fleet-89ab", "fleet-cdef"}, nil
+		}
+		return nil, nil
+	}
+
+	tests := []struct {
+		id      string
+		wantID  string
+		wantErr string
+	}{
+		{id: "farm-0123/fleet-4567", wantID: "fleet-4567"},
+		{id: "farm-0123/name:GPU Fleet", wantID: "fleet-4567"},
+		{id: "farm-0123/name:Render/GPU", wantID: "fleet-4567"},
+		{id: "farm-0123/name:Missing", wantErr: `No resource is named "Missing".`},
+		{id: "farm-0123/name:CPU Fleet", wantErr: "2 resources are named \"CPU Fleet\": fleet-89ab, fleet-cdef."},
+		{id: "farm-0123/name:", wantErr: "got an empty id"},
+		{id: "/name:GPU Fleet", wantErr: "got an empty farm_id"},
+		{id: "name:GPU Fleet", wantErr: "expected an ID in the format farm_id/id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: resourceSchema,
+					Raw:    tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil),
+				},
+			}
+			ImportCompositeIDOrName(ctx, resource.ImportStateRequest{ID: tt.id}, resp, byName, "farm_id", "id")
+			if tt.wantErr != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), tt.wantErr) {
+					t.Fatalf("ImportCompositeIDOrName() diagnostics = %v, want %q", resp.Diagnostics, tt.wantErr)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("ImportCompositeIDOrName() diagnostics = %v", resp.Diagnostics)
+			}
+			var farmID, id types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("farm_id"), &farmID)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			if farmID.ValueString() != "farm-0123" || id.ValueString() != tt.wantID {
+				t.Errorf("imported farm_id = %s, id = %s, want farm-0123, %s", farmID, id, tt.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tfresource holds helpers shared by the resource implementations.
+package tfresource
+
+import (
+	"errors"
+	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
+)
+
+// NotFound reports whether err is a Deadline ResourceNotFoundException, which
+// means the resource was deleted outside of Terraform.
+func NotFound(err error) bool {
+	var notFound *dltypes.ResourceNotFoundException
+	return errors.As(err, &notFound)
+}
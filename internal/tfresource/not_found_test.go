@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfresource
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
+)
+
+func TestNotFound(t *testing.T) {
+	notFound := &dltypes.ResourceNotFoundException{Message: new(string)}
+	if !NotFound(fmt.Errorf("operation error Deadline: GetFarm, %w", notFound)) {
+		t.Error("NotFound() = false for a wrapped ResourceNotFoundException")
+	}
+	if NotFound(&dltypes.AccessDeniedException{}) {
+		t.Error("NotFound() = true for an AccessDeniedException")
+	}
+	if NotFound(errors.New("boom")) {
+		t.Error("NotFound() = true for a generic error")
+	}
+}
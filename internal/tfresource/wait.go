@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfresource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// PollInterval is the delay between two status requests of WaitForStatus.
+var PollInterval = 10 * time.Second
+
+// StatusFunc returns the current status of a resource and the message the
+// service reported with it, if any.
+type StatusFunc func(ctx context.Context) (status string, message string, err error)
+
+// WaitForStatus polls refresh until it reports one of the target statuses,
+// or until timeout. Statuses in pending keep the wait going; any other status
+// fails it with the message refresh returned.
+//
+// An empty target waits for the resource to be deleted: polling goes on while
+// the resource reports a pending status and stops once refresh returns a not
+// found error. Any other status, for example a failed deletion, fails the wait.
+func WaitForStatus(ctx context.Context, timeout time.Duration, pending, target []string, refresh StatusFunc) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var last string
+	for {
+		status, message, err := refresh(ctx)
+		switch {
+		case err != nil && NotFound(err) && len(target) == 0:
+			return nil
+		case err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return timeoutError(timeout, target, last)
+		case err != nil:
+			return err
+		case slices.Contains(pending, status):
+		case slices.Contains(target, status):
+			return nil
+		case message != "":
+			return fmt.Errorf("unexpected status %s: %s", status, message)
+		default:
+			return fmt.Errorf("unexpected status %s", status)
+		}
+		last = status
+
+		select {
+		case <-ctx.Done():
+			return timeoutError(timeout, target, last)
+		case <-time.After(PollInterval):
+		}
+	}
+}
+
+func timeoutError(timeout time.Duration, target []string, last string) error {
+	if len(target) == 0 {
+		return fmt.Errorf("timeout after %s waiting for deletion (last status: %s)", timeout, last)
+	}
+	return fmt.Errorf("timeout after %s waiting for status %v (last status: %s)", timeout, target, last)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfresource
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
+)
+
+func TestWaitForStatus(t *testing.T) {
+	oldPollInterval := PollInterval
+	t.Cleanup(func() { PollInterval = oldPollInterval })
+	PollInterval = time.Millisecond
+	notFound := &dltypes.ResourceNotFoundException{Message: new(string)}
+
+	// sequence returns a StatusFunc reporting the given statuses in turn.
+	sequence := func(statuses ...string) StatusFunc {
+		return func(ctx context.Context) (string, string, error) {
+			status := statuses[0]
+			if len(statuses) > 1 {
+				statuses = statuses[1:]
+			}
+			if status == "" {
+				return "", "", notFound
+			}
+			return status, "quota exceeded", nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		pending []string
+		target  []string
+		refresh StatusFunc
+		timeout time.Duration
+		wantErr string
+	}{
+		{
+			name:    "target reached",
+			pending: []string{"CREATE_IN_PROGRESS"},
+			target:  []string{"ACTIVE"},
+			refresh: sequence("CREATE_IN_PROGRESS", "CREATE_IN_PROGRESS", "ACTIVE"),
+		},
+		{
+			name:    "failed status",
+			pending: []string{"CREATE_IN_PROGRESS"},
+			target:  []string{"ACTIVE"},
+			refresh: sequence("CREATE_IN_PROGRESS", "CREATE_FAILED"),
+			wantErr: "unexpected status CREATE_FAILED: quota exceeded",
+		},
+		{
+			name:    "deleted",
+			pending: []string{"ACTIVE", "DELETE_IN_PROGRESS"},
+			refresh: sequence("ACTIVE", "DELETE_IN_PROGRESS", ""),
+		},
+		{
+			name:    "deletion failed",
+			pending: []string{"ACTIVE", "DELETE_IN_PROGRESS"},
+			refresh: sequence("DELETE_IN_PROGRESS", "DELETE_FAILED", ""),
+			wantErr: "unexpected status DELETE_FAILED: quota exceeded",
+		},
+		{
+			name:    "not found while creating",
+			pending: []string{"CREATE_IN_PROGRESS"},
+			target:  []string{"ACTIVE"},
+			refresh: sequence(""),
+			wantErr: "ResourceNotFoundException",
+		},
+		{
+			name:    "timeout",
+			pending: []string{"CREATE_IN_PROGRESS"},
+			target:  []string{"ACTIVE"},
+			refresh: sequence("CREATE_IN_PROGRESS"),
+			timeout: 20 * time.Millisecond,
+			wantErr: "timeout after 20ms waiting for status [ACTIVE] (last status: CREATE_IN_PROGRESS)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = time.Minute
+			}
+			err := WaitForStatus(context.Background(), timeout, tt.pending, tt.target, tt.refresh)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("WaitForStatus() error = %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("WaitForStatus() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
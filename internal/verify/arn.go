@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package verify
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// partitions lists the AWS partitions an ARN may belong to.
+var partitions = map[string]bool{
+	"aws":        true,
+	"aws-cn":     true,
+	"aws-us-gov": true,
+	"aws-iso":    true,
+	"aws-iso-b":  true,
+	"aws-iso-e":  true,
+	"aws-iso-f":  true,
+	"aws-eusc":   true,
+}
+
+// ValidARN returns an error when value is not an ARN of a known partition, or
+// when service is not empty and the ARN belongs to another service.
+func ValidARN(value, service string) error {
+	parsed, err := arn.Parse(value)
+	if err != nil {
+		return err
+	}
+	if !partitions[parsed.Partition] {
+		return fmt.Errorf("unknown partition %q", parsed.Partition)
+	}
+	if service != "" && parsed.Service != service {
+		return fmt.Errorf("expected a %s ARN, got a %s ARN", service, parsed.Service)
+	}
+	return nil
+}
+
+// ARN returns a string validator accepting the ARNs of service in any
+// partition. An empty service accepts the ARNs of every service.
+func ARN(service string) validator.String {
+	return arnValidator{service: service}
+}
+
+type arnValidator struct {
+	service string
+}
+
+func (v arnValidator) Description(ctx context.Context) string {
+	if v.service == "" {
+		return "value must be an ARN"
+	}
+	return fmt.Sprintf("value must be a %s ARN", v.service)
+}
+
+func (v arnValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v arnValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if err := ValidARN(req.ConfigValue.ValueString(), v.service); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid ARN",
+			fmt.Sprintf("The value %q is not valid: %s.", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package verify
+
+import (
+	"testing"
+)
+
+func TestValidARN(t *testing.T) {
+	testCases := map[string]struct {
+		value   string
+		service string
+		wantErr bool
+	}{
+		"commercial":        {value: "arn:aws:iam::123456789012:role/worker", service: "iam"},
+		"govcloud":          {value: "arn:aws-us-gov:iam::123456789012:role/worker", service: "iam"},
+		"china":             {value: "arn:aws-cn:kms:cn-north-1:123456789012:key/abcd", service: "kms"},
+		"any service":       {value: "arn:aws:secretsmanager:us-west-2:123456789012:secret:pw"},
+		"wrong service":     {value: "arn:aws:iam::123456789012:role/worker", service: "kms", wantErr: true},
+		"unknown partition": {value: "arn:aws-moon:iam::123456789012:role/worker", service: "iam", wantErr: true},
+		"not an arn":        {value: "role/worker", service: "iam", wantErr: true},
+		"empty resource":    {value: "arn:aws:iam::123456789012", service: "iam", wantErr: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidARN(tc.value, tc.service)
+			if tc.wantErr && err == nil {
+				t.Errorf("ValidARN(%q) returned no error", tc.value)
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("ValidARN(%q) returned error: %s", tc.value, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package verify
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"time"
+)
+
+// Duration returns a string validator accepting positive Go durations, for
+// example "30s" or "1h30m".
+func Duration() validator.String {
+	return durationValidator{}
+}
+
+// DurationAtLeast returns a string validator accepting Go durations of at
+// least minimum.
+func DurationAtLeast(minimum time.Duration) validator.String {
+	return durationValidator{minimum: minimum}
+}
+
+type durationValidator struct {
+	// minimum is the shortest accepted duration. Zero accepts any positive
+	// duration.
+	minimum time.Duration
+}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	if v.minimum > 0 {
+		return fmt.Sprintf("value must be a duration of at least %s", v.minimum)
+	}
+	return "value must be a positive duration, for example 30s"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	if v.minimum > 0 {
+		return fmt.Sprintf("value must be a duration of at least `%s`", v.minimum)
+	}
+	return "value must be a positive duration, for example `30s`"
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	switch {
+	case err != nil:
+	case duration <= 0:
+		err = fmt.Errorf("the duration must be positive")
+	case duration < v.minimum:
+		err = fmt.Errorf("the duration must be at least %s", v.minimum)
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("The value %q is not valid: %s.", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
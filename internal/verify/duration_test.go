@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package verify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		validator validator.String
+		value     types.String
+		wantError bool
+	}{
+		{validator: Duration(), value: types.StringValue("30s")},
+		{validator: Duration(), value: types.StringValue("1h30m")},
+		{validator: Duration(), value: types.StringNull()},
+		{validator: Duration(), value: types.StringValue("30"), wantError: true},
+		{validator: Duration(), value: types.StringValue("0s"), wantError: true},
+		{validator: Duration(), value: types.StringValue("-5s"), wantError: true},
+		{validator: Duration(), value: types.StringValue(""), wantError: true},
+		{validator: DurationAtLeast(15 * time.Minute), value: types.StringValue("1h")},
+		{validator: DurationAtLeast(15 * time.Minute), value: types.StringValue("15m")},
+		{validator: DurationAtLeast(15 * time.Minute), value: types.StringValue("14m59s"), wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value.String(), func(t *testing.T) {
+			req := validator.StringRequest{ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+			tt.validator.ValidateString(context.Background(), req, resp)
+			if got := resp.Diagnostics.HasError(); got != tt.wantError {
+				t.Errorf("ValidateString(%s) error = %v, want %v: %v", tt.value, got, tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package verify
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// enum is implemented by the string enums of the AWS SDK.
+type enum[T any] interface {
+	~string
+	Values() []T
+}
+
+// EnumValues returns the values of the SDK enum T.
+func EnumValues[T enum[T]]() []string {
+	var zero T
+	values := zero.Values()
+	list := make([]string, 0, len(values))
+	for _, value := range values {
+		list = append(list, string(value))
+	}
+	return list
+}
+
+// Enum returns a validator that accepts the values of the SDK enum T.
+func Enum[T enum[T]]() validator.String {
+	return stringvalidator.OneOf(EnumValues[T]()...)
+}
+
+// EnumCaseInsensitive returns a validator that accepts the values of the SDK
+// enum T in any case, for attributes that document lower case values.
+func EnumCaseInsensitive[T enum[T]]() validator.String {
+	return stringvalidator.OneOfCaseInsensitive(EnumValues[T]()...)
+}
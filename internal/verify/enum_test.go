@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package verify
+
+import (
+	"context"
+	"testing"
+
+	dltypes "github.com/aws/aws-sdk-go-v2/service/deadline/types"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEnum(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator validator.String
+		value     string
+		wantError bool
+	}{
+		{name: "valid", validator: Enum[dltypes.MembershipLevel](), value: "OWNER"},
+		{name: "wrong case", validator: Enum[dltypes.MembershipLevel](), value: "owner", wantError: true},
+		{name: "unknown", validator: Enum[dltypes.MembershipLevel](), value: "ADMIN", wantError: true},
+		{name: "case insensitive", validator: EnumCaseInsensitive[dltypes.StorageProfileOperatingSystemFamily](), value: "linux"},
+		{name: "case insensitive unknown", validator: EnumCaseInsensitive[dltypes.StorageProfileOperatingSystemFamily](), value: "bsd", wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{ConfigValue: types.StringValue(tt.value)}
+			resp := &validator.StringResponse{}
+			tt.validator.ValidateString(context.Background(), req, resp)
+			if got := resp.Diagnostics.HasError(); got != tt.wantError {
+				t.Errorf("ValidateString(%q) error = %v, want %v: %v", tt.value, got, tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
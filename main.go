@@ -9,6 +9,7 @@ import (
 	"log"
 
 	"github.com/enable-la/terraform-provider-aws-deadline/internal/provider"
+	"github.com/enable-la/terraform-provider-aws-deadline/internal/tracing"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
@@ -32,8 +33,20 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	ctx := context.Background()
+	shutdownTracing, err := tracing.Init(ctx, version)
+	if err != nil {
+		// Tracing is a debugging aid; a broken OTLP configuration must not
+		// prevent the provider from running.
+		log.Printf("unable to initialize tracing, continuing without it: %s", err)
+		shutdownTracing = func(context.Context) error { return nil }
+	}
 
+	err = providerserver.Serve(ctx, provider.New(version), opts)
+
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		log.Printf("unable to flush traces: %s", shutdownErr)
+	}
 	if err != nil {
 		log.Fatal(err.Error())
 	}